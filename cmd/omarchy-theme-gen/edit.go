@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/theme"
+	"github.com/spf13/cobra"
+)
+
+func newSetSchemeCommand() *cobra.Command {
+	var scheme string
+
+	cmd := &cobra.Command{
+		Use:   "set-scheme <theme>",
+		Short: "Regenerate a theme with a different accent scheme",
+		Long: fmt.Sprintf(`Set-scheme re-opens a theme from its theme-gen.json and regenerates it with a
+new accent scheme, without re-reading the source image.
+
+Schemes: %s`, palette.SchemeNames()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := palette.ParseScheme(scheme)
+			if err != nil {
+				return err
+			}
+			return runRegenerate(cmd, args[0], theme.Changes{Scheme: s})
+		},
+	}
+
+	cmd.Flags().StringVarP(&scheme, "scheme", "s", "", "accent scheme")
+	cmd.MarkFlagRequired("scheme")
+
+	return cmd
+}
+
+func newSetModeCommand() *cobra.Command {
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "set-mode <theme>",
+		Short: "Regenerate a theme in light or dark mode",
+		Long: `Set-mode re-opens a theme from its theme-gen.json and regenerates it in the
+given mode, without re-reading the source image.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := processor.ParseThemeMode(mode)
+			if err != nil {
+				return err
+			}
+			return runRegenerate(cmd, args[0], theme.Changes{Mode: m})
+		},
+	}
+
+	cmd.Flags().StringVarP(&mode, "mode", "m", "", "theme mode: light or dark")
+	cmd.MarkFlagRequired("mode")
+
+	return cmd
+}
+
+func newCloneCommand() *cobra.Command {
+	var scheme, mode string
+
+	cmd := &cobra.Command{
+		Use:   "clone <source> <new-name>",
+		Short: "Duplicate a theme under a new name",
+		Long: `Clone regenerates an existing theme under a new name from its theme-gen.json,
+optionally with a different scheme or mode. The source theme is unchanged.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changes, err := parseChanges(scheme, mode)
+			if err != nil {
+				return err
+			}
+
+			s := settings.FromContext(cmd.Context())
+			path, err := theme.New(s).Clone(args[0], args[1], changes)
+			if err != nil {
+				return err
+			}
+
+			return printThemeSummary(cmd.OutOrStdout(), s, "Cloned "+args[0]+" to", args[1], path)
+		},
+	}
+
+	cmd.Flags().StringVarP(&scheme, "scheme", "s", "", "accent scheme (default: source scheme)")
+	cmd.Flags().StringVarP(&mode, "mode", "m", "", "theme mode: light or dark (default: source mode)")
+
+	return cmd
+}
+
+// runRegenerate regenerates a theme in place with the given changes.
+func runRegenerate(cmd *cobra.Command, name string, changes theme.Changes) error {
+	s := settings.FromContext(cmd.Context())
+	path, err := theme.New(s).Regenerate(name, changes)
+	if err != nil {
+		return err
+	}
+	return printThemeSummary(cmd.OutOrStdout(), s, "Regenerated", name, path)
+}
+
+// parseChanges parses optional scheme and mode flags.
+func parseChanges(scheme, mode string) (theme.Changes, error) {
+	var changes theme.Changes
+
+	if scheme != "" {
+		s, err := palette.ParseScheme(scheme)
+		if err != nil {
+			return changes, err
+		}
+		changes.Scheme = s
+	}
+
+	if mode != "" {
+		m, err := processor.ParseThemeMode(mode)
+		if err != nil {
+			return changes, err
+		}
+		changes.Mode = m
+	}
+
+	return changes, nil
+}
+
+// printThemeSummary reports the stored generation of a written theme.
+func printThemeSummary(w io.Writer, s *settings.Settings, action, name, path string) error {
+	meta, err := theme.New(s).LoadMetadata(name)
+	if err != nil {
+		return err
+	}
+
+	gen := meta.Generation
+	fmt.Fprintf(w, "%s theme %q (%s, %s) at %s\n", action, name, gen.Mode, gen.Scheme, path)
+	fmt.Fprintf(w, "  Background:       %s\n", gen.Background)
+	fmt.Fprintf(w, "  Foreground:       %s\n", gen.Foreground)
+	fmt.Fprintf(w, "  Accents:          %s %s %s\n", gen.Accent1, gen.Accent2, gen.Accent3)
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/loader"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/theme"
+	"github.com/spf13/cobra"
+)
+
+type generateOptions struct {
+	image  string
+	name   string
+	mode   string
+	scheme string
+	out    string
+	force  bool
+}
+
+func newGenerateCommand() *cobra.Command {
+	var opts generateOptions
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Create a theme from an image",
+		Long: `Generate extracts colors from an image, assigns semantic palette roles,
+and writes a complete Omarchy theme directory including theme-gen.json.
+
+The theme mode is detected from the image unless --mode is given. The accent
+scheme defaults to the color harmony the image already exhibits, falling back
+to accents taken directly from the image when no harmony is detected with
+confidence. Themes are written to the configured themes directory unless
+--out is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerate(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.image, "image", "i", "", "source image (jpeg, png, webp)")
+	flags.StringVarP(&opts.name, "name", "n", "", "theme name")
+	flags.StringVarP(&opts.mode, "mode", "m", "", "theme mode: light or dark (default: detected from image)")
+	flags.StringVarP(&opts.scheme, "scheme", "s", "", "accent scheme: "+palette.SchemeNames()+" (default: detected from image)")
+	flags.StringVarP(&opts.out, "out", "o", "", "themes directory for this theme (overrides --themes-dir)")
+	flags.BoolVarP(&opts.force, "force", "f", false, "replace an existing theme with the same name")
+
+	cmd.MarkFlagRequired("image")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagFilename("image", "jpg", "jpeg", "png", "webp")
+	cmd.MarkFlagDirname("out")
+
+	return cmd
+}
+
+// runGenerate runs the loader → processor → palette → theme pipeline.
+func runGenerate(cmd *cobra.Command, opts generateOptions) error {
+	ctx := cmd.Context()
+	s := settings.FromContext(ctx)
+	if opts.out != "" {
+		s.Theme.ThemesDir = opts.out
+	}
+
+	changes, err := parseChanges(opts.scheme, opts.mode)
+	if err != nil {
+		return err
+	}
+
+	img, err := loader.NewFileLoader(s).LoadImage(ctx, opts.image)
+	if err != nil {
+		return err
+	}
+
+	profile, err := processor.New(s).ProcessImageContext(ctx, img, processor.ProcessOptions{})
+	if err != nil {
+		return fmt.Errorf("process %s: %w", opts.image, err)
+	}
+
+	// Build from a copy so the metadata keeps the detected mode
+	target := *profile
+	if changes.Mode != "" {
+		target.Mode = changes.Mode
+	}
+
+	generator := palette.New(s)
+	detected, confidence := generator.DetectScheme(profile)
+	if changes.Scheme == "" {
+		changes.Scheme = detected
+	}
+
+	pal, err := generator.BuildWithOptions(&target, palette.Options{Scheme: changes.Scheme})
+	if err != nil {
+		return err
+	}
+
+	writer := theme.New(s)
+	write := writer.Write
+	if opts.force {
+		write = writer.Replace
+	}
+
+	path, err := write(opts.name, profile, pal, opts.image)
+	if err != nil {
+		return err
+	}
+
+	if err := printThemeSummary(cmd.OutOrStdout(), s, "Generated", opts.name, path); err != nil {
+		return err
+	}
+	printDiagnostics(cmd.OutOrStdout(), profile, pal, detected, confidence)
+	return nil
+}
+
+// printDiagnostics reports extraction, harmony, contrast, and color vision
+// deficiency details for a new theme.
+func printDiagnostics(w io.Writer, profile *processor.ColorProfile, pal *palette.SemanticPalette, detected palette.Scheme, confidence float64) {
+	fmt.Fprintf(w, "  Colors extracted: %d\n", profile.ColorCount)
+	fmt.Fprintf(w, "  Detected scheme:  %s (%.0f%% confidence)\n", detected, confidence*100)
+	if fg, ok := pal.Contrast.Find("foreground"); ok {
+		fmt.Fprintf(w, "  Text contrast:    %.2f:1 (APCA Lc %.0f)\n", fg.AdjustedRatio, fg.AdjustedLc)
+	}
+
+	if failures := pal.Contrast.Failures(); len(failures) > 0 {
+		fmt.Fprintf(w, "  Warning: %d color(s) could not meet their contrast level\n", len(failures))
+	}
+
+	for _, d := range chromatic.Deficiencies {
+		if conflicts := pal.Deficiencies.For(d); len(conflicts) > 0 {
+			fmt.Fprintf(w, "  Warning: %d color pair(s) indistinguishable with %s\n", len(conflicts), d)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Command omarchy-theme-gen generates Omarchy themes from images.
+//
+// Usage:
+//
+//	omarchy-theme-gen generate --image photo.jpg --name mytheme [--mode light|dark] [--out dir]
+//
+// Settings are loaded from the standard configuration search paths
+// (see pkg/settings) and may be overridden with OMARCHY_THEME_GEN_*
+// environment variables.
+package main
+
+import "os"
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
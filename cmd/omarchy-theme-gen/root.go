@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// newRootCommand builds the omarchy-theme-gen command tree. Settings are
+// loaded once before any subcommand runs and passed through the command
+// context.
+func newRootCommand() *cobra.Command {
+	var themesDir string
+
+	root := &cobra.Command{
+		Use:          "omarchy-theme-gen",
+		Short:        "Generate Omarchy themes from images",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			s, err := settings.Load()
+			if err != nil {
+				return err
+			}
+			if themesDir != "" {
+				s.Theme.ThemesDir = themesDir
+			}
+			cmd.SetContext(settings.WithSettings(cmd.Context(), s))
+			return nil
+		},
+	}
+
+	root.PersistentFlags().StringVarP(&themesDir, "themes-dir", "d", "", "themes directory (default: settings theme.themes_dir)")
+	root.MarkPersistentFlagDirname("themes-dir")
+
+	root.AddCommand(
+		newGenerateCommand(),
+		newSetSchemeCommand(),
+		newSetModeCommand(),
+		newCloneCommand(),
+	)
+
+	return root
+}
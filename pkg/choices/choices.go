@@ -0,0 +1,15 @@
+package choices
+
+import "strings"
+
+// Lookup returns the entry of names that matches name, ignoring case and
+// surrounding whitespace, so "aa" and " AA " both resolve to "AA".
+func Lookup(names []string, name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return n, true
+		}
+	}
+	return "", false
+}
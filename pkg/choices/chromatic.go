@@ -0,0 +1,26 @@
+package choices
+
+// Accessibility level names for chromatic.AccessibilityLevel and the palette
+// contrast level settings.
+const (
+	AAA      = "AAA"
+	AA       = "AA"
+	AAALarge = "AAA-large"
+	AALarge  = "AA-large"
+	NonText  = "non-text"
+)
+
+// AccessibilityLevels lists the accessibility level names from strictest to
+// most lenient.
+var AccessibilityLevels = []string{AAA, AA, AAALarge, AALarge, NonText}
+
+// Distance metric names for chromatic.DistanceMetric and the
+// chromatic.distance_metric setting.
+const (
+	CIE76     = "cie76"
+	CIE94     = "cie94"
+	CIEDE2000 = "ciede2000"
+)
+
+// DistanceMetrics lists the distance metric names.
+var DistanceMetrics = []string{CIE76, CIE94, CIEDE2000}
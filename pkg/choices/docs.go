@@ -0,0 +1,24 @@
+// Package choices defines the names accepted by enumerated settings for the
+// Omarchy Theme Generator.
+//
+// This package is the single source of truth for those names. The settings
+// package validates configured values against these lists, and the packages
+// that interpret the values declare their typed constants from the same
+// names, so a name cannot be added or renamed in one place and missed in the
+// other. Like pkg/errors, it imports no other package in this module, so every
+// layer can depend on it without creating circular dependencies. Names are
+// grouped by the package that interprets them, with each package in its own
+// file. Lookup matches a configured value against a list ignoring case and
+// surrounding whitespace, so validation and parsing accept the same
+// spellings.
+//
+// # Usage Patterns
+//
+// Typed constants take their values from the shared names:
+//
+//	const AA AccessibilityLevel = choices.AA
+//
+// Validation lists the allowed names in its error messages:
+//
+//	strings.Join(choices.AccessibilityLevels, ", ")
+package choices
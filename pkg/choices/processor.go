@@ -0,0 +1,43 @@
+package choices
+
+// Extractor names for processor.ExtractionMethod and the processor.extractor
+// setting.
+const (
+	Histogram = "histogram"
+	MedianCut = "median-cut"
+	Octree    = "octree"
+)
+
+// Extractors lists the extractor names.
+var Extractors = []string{Histogram, MedianCut, Octree}
+
+// Spatial weighting names for processor.SpatialWeighting and the
+// processor.spatial_weighting setting.
+const (
+	UniformWeighting  = "uniform"
+	CenterWeighting   = "center"
+	EdgeWeighting     = "edge"
+	SaliencyWeighting = "saliency"
+)
+
+// SpatialWeightings lists the spatial weighting names.
+var SpatialWeightings = []string{UniformWeighting, CenterWeighting, EdgeWeighting, SaliencyWeighting}
+
+// Clustering algorithm names for processor.ClusteringAlgorithm and the
+// processor.clustering_algorithm setting.
+const (
+	Greedy = "greedy"
+	KMeans = "kmeans"
+)
+
+// ClusteringAlgorithms lists the clustering algorithm names.
+var ClusteringAlgorithms = []string{Greedy, KMeans}
+
+// K-means color space names for the processor.kmeans_color_space setting.
+const (
+	LAB   = "lab"
+	OKLab = "oklab"
+)
+
+// KMeansColorSpaces lists the k-means color space names.
+var KMeansColorSpaces = []string{LAB, OKLab}
@@ -0,0 +1,114 @@
+package chromatic
+
+import (
+	"image/color"
+	"math"
+)
+
+// APCALevel represents an APCA (WCAG 3 draft) lightness contrast threshold.
+// Levels are minimum absolute Lc values for a use case and apply to both
+// polarities.
+type APCALevel string
+
+const (
+	// APCAFluent requires Lc 90, preferred for body text and long reading
+	APCAFluent APCALevel = "Lc90"
+	// APCABody requires Lc 75, the minimum for body text
+	APCABody APCALevel = "Lc75"
+	// APCAContent requires Lc 60, the minimum for content text that is not body text
+	APCAContent APCALevel = "Lc60"
+	// APCALarge requires Lc 45, the minimum for large or heavy text such as headlines
+	APCALarge APCALevel = "Lc45"
+	// APCASpot requires Lc 30, the minimum for spot-readable text such as placeholders
+	APCASpot APCALevel = "Lc30"
+	// APCANonText requires Lc 15, the minimum for discernible non-text elements
+	APCANonText APCALevel = "Lc15"
+)
+
+// Lc returns the minimum absolute lightness contrast for the level.
+// Returns 75 for APCABody (default).
+func (a APCALevel) Lc() float64 {
+	switch a {
+	case APCAFluent:
+		return 90
+	case APCAContent:
+		return 60
+	case APCALarge:
+		return 45
+	case APCASpot:
+		return 30
+	case APCANonText:
+		return 15
+	default:
+		return 75
+	}
+}
+
+// APCA 0.0.98G-4g constants.
+const (
+	apcaBlackThreshold = 0.022
+	apcaBlackClamp     = 1.414
+	apcaDeltaYMin      = 0.0005
+	apcaNormBG         = 0.56
+	apcaNormText       = 0.57
+	apcaRevText        = 0.62
+	apcaRevBG          = 0.65
+	apcaScale          = 1.14
+	apcaLowOffset      = 0.027
+	apcaLowClip        = 0.1
+)
+
+// APCAContrast calculates the APCA lightness contrast (Lc) of text on a
+// background. The result is polarity aware: positive values are dark text on
+// a light background, negative values are light text on a dark background.
+// Magnitudes range from 0 (no contrast) to about 106 for black on white and
+// 108 for white on black. Unlike ContrastRatio, Lc is not symmetric, and it
+// tracks perceived contrast of light text on dark backgrounds much more
+// closely.
+func APCAContrast(text, bg color.RGBA) float64 {
+	yText := apcaClampBlack(apcaLuminance(text))
+	yBG := apcaClampBlack(apcaLuminance(bg))
+
+	if math.Abs(yBG-yText) < apcaDeltaYMin {
+		return 0
+	}
+
+	var lc float64
+	if yBG > yText {
+		sapc := (math.Pow(yBG, apcaNormBG) - math.Pow(yText, apcaNormText)) * apcaScale
+		if sapc >= apcaLowClip {
+			lc = sapc - apcaLowOffset
+		}
+	} else {
+		sapc := (math.Pow(yBG, apcaRevBG) - math.Pow(yText, apcaRevText)) * apcaScale
+		if sapc <= -apcaLowClip {
+			lc = sapc + apcaLowOffset
+		}
+	}
+
+	return lc * 100
+}
+
+// MeetsAPCA checks if text on a background meets the APCA level in either
+// polarity.
+func MeetsAPCA(text, bg color.RGBA, level APCALevel) bool {
+	return math.Abs(APCAContrast(text, bg)) >= level.Lc()
+}
+
+// apcaLuminance estimates screen luminance with the simple 2.4 power curve
+// APCA specifies instead of the piecewise sRGB transfer function.
+func apcaLuminance(c color.RGBA) float64 {
+	r := math.Pow(float64(c.R)/255.0, 2.4)
+	g := math.Pow(float64(c.G)/255.0, 2.4)
+	b := math.Pow(float64(c.B)/255.0, 2.4)
+
+	return 0.2126729*r + 0.7151522*g + 0.0721750*b
+}
+
+// apcaClampBlack softly raises near-black luminance to model flare.
+func apcaClampBlack(y float64) float64 {
+	if y >= apcaBlackThreshold {
+		return y
+	}
+	return y + math.Pow(apcaBlackThreshold-y, apcaBlackClamp)
+}
@@ -20,7 +20,8 @@ func NewChroma(s *settings.Settings) *Chroma {
 
 // ColorsSimilar determines if two colors should be clustered together using
 // perceptual distance metrics with special handling for neutral colors.
-// Uses LAB color space for accurate perceptual similarity assessment.
+// Chromatic colors are compared with the configured distance metric against
+// that metric's merge threshold.
 func (c *Chroma) ColorsSimilar(c1, c2 color.RGBA) bool {
 	// Special handling for neutrals
 	h1 := formats.RGBAToHSLA(c1)
@@ -31,7 +32,7 @@ func (c *Chroma) ColorsSimilar(c1, c2 color.RGBA) bool {
 		return math.Abs(h1.L-h2.L) < c.settings.Chromatic.NeutralLightnessThreshold
 	}
 
-	// Use LAB distance for perceptual similarity
-	distance := DistanceLAB(c1, c2)
-	return distance <= c.settings.Chromatic.ColorMergeThreshold
+	// Use the configured LAB metric for perceptual similarity
+	distance := c.Distance(c1, c2)
+	return distance <= c.MergeThreshold()
 }
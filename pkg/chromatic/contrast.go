@@ -1,33 +1,67 @@
 package chromatic
 
 import (
+	"fmt"
 	"image/color"
 	"math"
+	"strings"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
 )
 
 // AccessibilityLevel represents WCAG contrast ratio requirements for different
 // accessibility conformance levels. Each level defines minimum contrast ratios
-// for text readability.
+// for text readability or, for NonText, for user interface components.
 type AccessibilityLevel string
 
 const (
 	// AA requires 4.5:1 contrast ratio for normal text (WCAG 2.1 Level AA)
-	AA AccessibilityLevel = "AA"
+	AA AccessibilityLevel = choices.AA
 	// AAA requires 7.0:1 contrast ratio for normal text (WCAG 2.1 Level AAA)
-	AAA AccessibilityLevel = "AAA"
+	AAA AccessibilityLevel = choices.AAA
 	// AALarge requires 3.0:1 contrast ratio for large text (WCAG 2.1 Level AA)
-	AALarge AccessibilityLevel = "AA-large"
+	AALarge AccessibilityLevel = choices.AALarge
 	// AAALarge requires 4.5:1 contrast ratio for large text (WCAG 2.1 Level AAA)
-	AAALarge AccessibilityLevel = "AAA-large"
+	AAALarge AccessibilityLevel = choices.AAALarge
+	// NonText requires 3.0:1 contrast ratio for UI components such as borders
+	// and focus rings (WCAG 2.1 Success Criterion 1.4.11)
+	NonText AccessibilityLevel = choices.NonText
 )
 
+// AccessibilityLevels lists every accessibility level from strictest to most
+// lenient, in the order of choices.AccessibilityLevels.
+var AccessibilityLevels = accessibilityLevels()
+
+func accessibilityLevels() []AccessibilityLevel {
+	levels := make([]AccessibilityLevel, len(choices.AccessibilityLevels))
+	for i, name := range choices.AccessibilityLevels {
+		levels[i] = AccessibilityLevel(name)
+	}
+	return levels
+}
+
+// ParseAccessibilityLevel parses a case-insensitive accessibility level name
+// such as "AA" or "non-text". Unknown names are an error listing the valid
+// levels.
+func ParseAccessibilityLevel(name string) (AccessibilityLevel, error) {
+	if level, ok := choices.Lookup(choices.AccessibilityLevels, name); ok {
+		return AccessibilityLevel(level), nil
+	}
+	return "", fmt.Errorf("invalid accessibility level %q: expected one of %s",
+		name, strings.Join(choices.AccessibilityLevels, ", "))
+}
+
 // Ratio returns the minimum contrast ratio required for the accessibility level.
-// Returns 4.5 for AA (default), 7.0 for AAA, and 3.0 for AA-large.
+// Returns 4.5 for AA (default) and AAA-large, 7.0 for AAA, and 3.0 for
+// AA-large and non-text. Use ParseAccessibilityLevel to reject unknown names
+// rather than falling back to AA.
 func (a AccessibilityLevel) Ratio() float64 {
 	switch a {
 	case AAA:
 		return 7.0
-	case AALarge:
+	case AAALarge:
+		return 4.5
+	case AALarge, NonText:
 		return 3.0
 	default:
 		return 4.5
@@ -42,6 +76,21 @@ func IsAccessible(c1, c2 color.RGBA, level AccessibilityLevel) bool {
 	return ratio >= level.Ratio()
 }
 
+// MeetsLevels returns every accessibility level the contrast between c1 and c2
+// satisfies, ordered from strictest to most lenient. Returns an empty slice
+// when no level is met.
+func MeetsLevels(c1, c2 color.RGBA) []AccessibilityLevel {
+	ratio := ContrastRatio(c1, c2)
+
+	levels := []AccessibilityLevel{}
+	for _, level := range AccessibilityLevels {
+		if ratio >= level.Ratio() {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
 // Luminance calculates the relative luminance of a color according to WCAG 2.1.
 // Returns a value between 0 (black) and 1 (white).
 //
@@ -0,0 +1,151 @@
+package chromatic
+
+import (
+	"image/color"
+	"math"
+)
+
+// Deficiency identifies a type of color vision deficiency (CVD).
+type Deficiency string
+
+const (
+	// Protanopia is the absence of long-wavelength (red) cones
+	Protanopia Deficiency = "protanopia"
+	// Deuteranopia is the absence of medium-wavelength (green) cones
+	Deuteranopia Deficiency = "deuteranopia"
+	// Tritanopia is the absence of short-wavelength (blue) cones
+	Tritanopia Deficiency = "tritanopia"
+)
+
+// Deficiencies lists every simulated color vision deficiency.
+var Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+
+// cvdMatrices holds the Machado, Oliveira, and Fernandes (2009) simulation
+// matrices at full severity, applied to linear RGB.
+var cvdMatrices = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// SimulateDeficiency returns how c appears to a viewer with the deficiency,
+// using the Machado 2009 model at full severity. Unknown deficiencies return
+// c unchanged. Alpha is preserved.
+func SimulateDeficiency(c color.RGBA, d Deficiency) color.RGBA {
+	m, ok := cvdMatrices[d]
+	if !ok {
+		return c
+	}
+
+	r := linearize(float64(c.R) / 255.0)
+	g := linearize(float64(c.G) / 255.0)
+	b := linearize(float64(c.B) / 255.0)
+
+	return color.RGBA{
+		R: delinearize(m[0][0]*r + m[0][1]*g + m[0][2]*b),
+		G: delinearize(m[1][0]*r + m[1][1]*g + m[1][2]*b),
+		B: delinearize(m[2][0]*r + m[2][1]*g + m[2][2]*b),
+		A: c.A,
+	}
+}
+
+// NamedColor pairs a color with the role it fills, for reporting.
+type NamedColor struct {
+	Name  string
+	Color color.RGBA
+}
+
+// DeficiencyConflict records two colors that are distinct for typical color
+// vision but collapse under a deficiency.
+type DeficiencyConflict struct {
+	Deficiency Deficiency
+	First      NamedColor
+	Second     NamedColor
+	Original   float64 // CIEDE2000 distance for typical color vision
+	Simulated  float64 // CIEDE2000 distance under the deficiency
+}
+
+// DeficiencyReport collects the conflicts found across every deficiency.
+type DeficiencyReport struct {
+	Conflicts []DeficiencyConflict
+}
+
+// For returns the conflicts found under the deficiency.
+func (r DeficiencyReport) For(d Deficiency) []DeficiencyConflict {
+	var conflicts []DeficiencyConflict
+	for _, c := range r.Conflicts {
+		if c.Deficiency == d {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// Safe reports whether no conflicts were found.
+func (r DeficiencyReport) Safe() bool {
+	return len(r.Conflicts) == 0
+}
+
+// CheckDeficiencies compares every pair of colors under each deficiency and
+// reports the pairs whose CIEDE2000 distance falls below the configured
+// deficiency distance threshold. Pairs already below the threshold for typical
+// color vision are not reported, since the deficiency did not cause them.
+// CIEDE2000 is used regardless of the configured distance metric so the
+// threshold has a single meaning.
+func (c *Chroma) CheckDeficiencies(colors []NamedColor) DeficiencyReport {
+	threshold := c.settings.Chromatic.DeficiencyDistanceThreshold
+
+	var report DeficiencyReport
+	for _, d := range Deficiencies {
+		simulated := make([]color.RGBA, len(colors))
+		for i, nc := range colors {
+			simulated[i] = SimulateDeficiency(nc.Color, d)
+		}
+
+		for i := 0; i < len(colors); i++ {
+			for j := i + 1; j < len(colors); j++ {
+				original := DistanceCIEDE2000(colors[i].Color, colors[j].Color)
+				if original < threshold {
+					continue
+				}
+
+				distance := DistanceCIEDE2000(simulated[i], simulated[j])
+				if distance < threshold {
+					report.Conflicts = append(report.Conflicts, DeficiencyConflict{
+						Deficiency: d,
+						First:      colors[i],
+						Second:     colors[j],
+						Original:   original,
+						Simulated:  distance,
+					})
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+// delinearize converts a linear RGB value to an 8-bit sRGB channel, clipping
+// values outside [0-1].
+func delinearize(v float64) uint8 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(v * 255))
+}
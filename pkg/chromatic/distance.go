@@ -4,6 +4,7 @@ import (
 	"image/color"
 	"math"
 
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
 	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
 )
 
@@ -59,6 +60,169 @@ func DistanceLAB(c1, c2 color.RGBA) float64 {
 	return math.Sqrt(dl*dl + da*da + db*db)
 }
 
+// DistanceMetric selects the color difference formula used by Chroma.Distance.
+type DistanceMetric string
+
+const (
+	// CIE76 is Euclidean distance in LAB space (DistanceLAB).
+	CIE76 DistanceMetric = choices.CIE76
+	// CIE94 weights chroma and hue differences by chroma (DistanceCIE94).
+	CIE94 DistanceMetric = choices.CIE94
+	// CIEDE2000 corrects LAB non-uniformity in blues, neutrals, and saturated
+	// colors (DistanceCIEDE2000).
+	CIEDE2000 DistanceMetric = choices.CIEDE2000
+)
+
+// Distance returns the difference between two colors using the metric
+// configured in chromatic.distance_metric. Settings.Validate rejects unknown
+// metrics when settings load; settings built in code that skip validation
+// use CIE76.
+func (c *Chroma) Distance(c1, c2 color.RGBA) float64 {
+	switch DistanceMetric(c.settings.Chromatic.DistanceMetric) {
+	case CIE94:
+		return DistanceCIE94(c1, c2)
+	case CIEDE2000:
+		return DistanceCIEDE2000(c1, c2)
+	default:
+		return DistanceLAB(c1, c2)
+	}
+}
+
+// MergeThreshold returns the distance at or below which ColorsSimilar treats
+// chromatic colors as the same, in the units of the configured metric. CIE94
+// and CIEDE2000 report roughly half the CIE76 distance for the same pair, so
+// each metric has its own threshold: chromatic.color_merge_threshold for
+// CIE76, chromatic.cie94_merge_threshold, and
+// chromatic.ciede2000_merge_threshold. Unknown metrics use the CIE76
+// threshold, matching Distance.
+func (c *Chroma) MergeThreshold() float64 {
+	switch DistanceMetric(c.settings.Chromatic.DistanceMetric) {
+	case CIE94:
+		return c.settings.Chromatic.CIE94MergeThreshold
+	case CIEDE2000:
+		return c.settings.Chromatic.CIEDE2000MergeThreshold
+	default:
+		return c.settings.Chromatic.ColorMergeThreshold
+	}
+}
+
+// DistanceCIE94 calculates the CIE94 color difference using graphic arts
+// weights. Chroma and hue differences are scaled down for saturated colors,
+// where CIE76 overstates perceived differences.
+func DistanceCIE94(c1, c2 color.RGBA) float64 {
+	return DeltaE94(formats.RGBAToLAB(c1), formats.RGBAToLAB(c2))
+}
+
+// DistanceCIEDE2000 calculates the CIEDE2000 color difference, the most
+// perceptually accurate of the LAB metrics. Values are typically smaller
+// than CIE76 for the same pair of colors.
+func DistanceCIEDE2000(c1, c2 color.RGBA) float64 {
+	return DeltaE2000(formats.RGBAToLAB(c1), formats.RGBAToLAB(c2))
+}
+
+// DeltaE94 calculates the CIE94 difference between two LAB colors with
+// graphic arts weights (kL=1, K1=0.045, K2=0.015). The first color is the
+// reference, so the result is not symmetric.
+func DeltaE94(lab1, lab2 formats.LAB) float64 {
+	const k1, k2 = 0.045, 0.015
+
+	c1 := math.Hypot(lab1.A, lab1.B)
+	c2 := math.Hypot(lab2.A, lab2.B)
+
+	dl := lab1.L - lab2.L
+	dc := c1 - c2
+	da := lab1.A - lab2.A
+	db := lab1.B - lab2.B
+	dh2 := math.Max(da*da+db*db-dc*dc, 0)
+
+	sc := 1 + k1*c1
+	sh := 1 + k2*c1
+
+	return math.Sqrt(dl*dl + (dc/sc)*(dc/sc) + dh2/(sh*sh))
+}
+
+// DeltaE2000 calculates the CIEDE2000 difference between two LAB colors with
+// unit parametric weights, following Sharma, Wu, and Dalal (2005).
+func DeltaE2000(lab1, lab2 formats.LAB) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+
+	cBar := (math.Hypot(lab1.A, lab1.B) + math.Hypot(lab2.A, lab2.B)) / 2
+	cBar7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+pow25to7)))
+
+	a1 := (1 + g) * lab1.A
+	a2 := (1 + g) * lab2.A
+	c1 := math.Hypot(a1, lab1.B)
+	c2 := math.Hypot(a2, lab2.B)
+	h1 := primeHue(a1, lab1.B)
+	h2 := primeHue(a2, lab2.B)
+
+	dl := lab2.L - lab1.L
+	dc := c2 - c1
+
+	var dh float64
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(degToRad(dh/2))
+
+	lBar := (lab1.L + lab2.L) / 2
+	cBarPrime := (c1 + c2) / 2
+
+	hBar := h1 + h2
+	if c1*c2 != 0 {
+		if math.Abs(h1-h2) <= 180 {
+			hBar /= 2
+		} else if h1+h2 < 360 {
+			hBar = (h1 + h2 + 360) / 2
+		} else {
+			hBar = (h1 + h2 - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(degToRad(hBar-30)) +
+		0.24*math.Cos(degToRad(2*hBar)) +
+		0.32*math.Cos(degToRad(3*hBar+6)) -
+		0.20*math.Cos(degToRad(4*hBar-63))
+
+	dTheta := 30 * math.Exp(-((hBar-275)/25)*((hBar-275)/25))
+	cBarPrime7 := math.Pow(cBarPrime, 7)
+	rc := 2 * math.Sqrt(cBarPrime7/(cBarPrime7+pow25to7))
+	l50 := (lBar - 50) * (lBar - 50)
+	sl := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sc := 1 + 0.045*cBarPrime
+	sh := 1 + 0.015*cBarPrime*t
+	rt := -math.Sin(degToRad(2*dTheta)) * rc
+
+	lTerm := dl / sl
+	cTerm := dc / sc
+	hTerm := dH / sh
+
+	return math.Sqrt(lTerm*lTerm + cTerm*cTerm + hTerm*hTerm + rt*cTerm*hTerm)
+}
+
+// primeHue returns the CIEDE2000 hue angle in degrees [0-360).
+func primeHue(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// degToRad converts degrees to radians.
+func degToRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
 // hueDistance calculates the shortest angular distance between two hues in degrees.
 // Accounts for the circular nature of hue (0° = 360°) by taking the minimum
 // of clockwise and counterclockwise distances. Returns value in range [0-180].
@@ -9,15 +9,24 @@
 // Key Features:
 //   - Perceptual color similarity using LAB color space
 //   - Specialized neutral color clustering with lightness thresholds
-//   - WCAG 2.1 accessibility compliance (AA/AAA levels)
-//   - Multiple distance metrics (RGB, HSL, LAB)
+//   - WCAG 2.1 accessibility compliance (AA/AAA text and non-text UI levels)
+//   - APCA (WCAG 3 draft) lightness contrast with polarity-aware Lc values
+//   - Contrast enforcement that repairs failing pairs by adjusting LAB lightness
+//   - Color vision deficiency simulation (protanopia, deuteranopia,
+//     tritanopia) and detection of colors that collapse under each
+//   - Multiple distance metrics (RGB, HSL, CIE76, CIE94, CIEDE2000)
 //   - Hue analysis and variance calculations
+//   - Harmony generation (complementary, split-complementary, analogous,
+//     triadic, tetradic, monochromatic) from a base color
+//   - Harmony detection with confidence scoring from weighted hues
 //
 // Color Similarity:
 //
 // The ColorsSimilar method implements a two-tier approach:
 //   - Neutral colors (low saturation) use lightness difference thresholds
-//   - Saturated colors use LAB color space distance for perceptual accuracy
+//   - Saturated colors use the LAB metric selected by
+//     chromatic.distance_metric (cie76, cie94, or ciede2000), compared
+//     against that metric's merge threshold (15, 7.5, and 7 by default)
 //
 // Usage:
 //
@@ -34,8 +43,41 @@
 //	    // Meets WCAG 2.1 AA requirements
 //	}
 //
+//	// List every level a pair satisfies, strictest first
+//	levels := chromatic.MeetsLevels(fg, bg)
+//
+//	// Score text with APCA; negative Lc is light text on a dark background
+//	lc := chromatic.APCAContrast(fg, bg)
+//	if chromatic.MeetsAPCA(fg, bg, chromatic.APCABody) {
+//	    // |Lc| is at least 75, suitable for body text
+//	}
+//
+//	// Repair a failing pair while preserving hue
+//	adjusted := chromatic.EnforceContrast(fg, bg, chromatic.AA)
+//	if adjusted.Moved() {
+//	    fg = adjusted.Adjusted
+//	}
+//
+//	// Find colors that become indistinguishable with color blindness
+//	report := chroma.CheckDeficiencies(colors)
+//	for _, conflict := range report.For(chromatic.Deuteranopia) {
+//	    // conflict.First and conflict.Second look alike
+//	}
+//
+//	// Derive harmonious colors from a base
+//	triad := chroma.Triadic(formats.RGBAToHSLA(accent))
+//	analogous := chroma.Analogous(formats.RGBAToHSLA(accent))
+//
+//	// Classify the harmony among weighted hues
+//	match := chroma.DetectHarmony(hues)
+//	if match.Confidence > 0.5 {
+//	    // Hues follow match.Harmony anchored at match.Base
+//	}
+//
 //	// Calculate perceptual distance
 //	distance := chromatic.DistanceLAB(color1, color2)
+//	deltaE := chromatic.DistanceCIEDE2000(color1, color2)
+//	configured := chroma.Distance(color1, color2)
 //
 // The package follows the settings-as-methods pattern, requiring configuration
 // through the Chroma struct for operations requiring thresholds.
@@ -0,0 +1,261 @@
+package chromatic
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// Harmony identifies a color harmony relationship between hues.
+type Harmony string
+
+const (
+	HarmonyComplementary      Harmony = "complementary"
+	HarmonySplitComplementary Harmony = "split-complementary"
+	HarmonyAnalogous          Harmony = "analogous"
+	HarmonyTriadic            Harmony = "triadic"
+	HarmonyTetradic           Harmony = "tetradic"
+	HarmonyMonochromatic      Harmony = "monochromatic"
+)
+
+// Harmonies lists every supported harmony.
+var Harmonies = []Harmony{
+	HarmonyComplementary,
+	HarmonySplitComplementary,
+	HarmonyAnalogous,
+	HarmonyTriadic,
+	HarmonyTetradic,
+	HarmonyMonochromatic,
+}
+
+// Monochromatic variants stay within this lightness range to remain usable
+// as accents on both light and dark surfaces, trying at most
+// monochromaticMaxSteps lightness steps before clamping.
+const (
+	monochromaticLightnessMin = 0.2
+	monochromaticLightnessMax = 0.85
+	monochromaticMaxSteps     = 8
+)
+
+// ParseHarmony parses a case-insensitive harmony name.
+func ParseHarmony(name string) (Harmony, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, h := range Harmonies {
+		if string(h) == name {
+			return h, nil
+		}
+	}
+	return "", fmt.Errorf("invalid harmony %q", name)
+}
+
+// Harmony generates the color set for the given harmony. The base color is
+// always the first element; derived colors follow in a fixed order.
+func (c *Chroma) Harmony(h Harmony, base formats.HSLA) ([]formats.HSLA, error) {
+	switch h {
+	case HarmonyComplementary:
+		return c.Complementary(base), nil
+	case HarmonySplitComplementary:
+		return c.SplitComplementary(base), nil
+	case HarmonyAnalogous:
+		return c.Analogous(base), nil
+	case HarmonyTriadic:
+		return c.Triadic(base), nil
+	case HarmonyTetradic:
+		return c.Tetradic(base), nil
+	case HarmonyMonochromatic:
+		return c.Monochromatic(base), nil
+	default:
+		return nil, fmt.Errorf("invalid harmony %q", h)
+	}
+}
+
+// Complementary returns the base and the hue directly opposite it.
+func (c *Chroma) Complementary(base formats.HSLA) []formats.HSLA {
+	return rotations(base, 180)
+}
+
+// SplitComplementary returns the base and the two hues either side of its
+// complement, separated from it by the configured split angle.
+func (c *Chroma) SplitComplementary(base formats.HSLA) []formats.HSLA {
+	split := c.settings.Chromatic.SplitComplementaryAngle
+	return rotations(base, 180-split, 180+split)
+}
+
+// Analogous returns the base and its neighbors on either side, separated by
+// the configured analogous spread.
+func (c *Chroma) Analogous(base formats.HSLA) []formats.HSLA {
+	spread := c.settings.Chromatic.AnalogousSpread
+	return rotations(base, spread, -spread)
+}
+
+// Triadic returns the base and the two hues spaced 120° around the wheel.
+func (c *Chroma) Triadic(base formats.HSLA) []formats.HSLA {
+	return rotations(base, 120, 240)
+}
+
+// Tetradic returns the base and three hues forming a square on the wheel.
+func (c *Chroma) Tetradic(base formats.HSLA) []formats.HSLA {
+	return rotations(base, 90, 180, 270)
+}
+
+// Monochromatic returns the base and two variants on the same hue, stepped
+// in lightness by the configured monochromatic lightness step. Steps
+// alternate lighter and darker, widening as needed so both variants stay in
+// the usable lightness range.
+func (c *Chroma) Monochromatic(base formats.HSLA) []formats.HSLA {
+	step := c.settings.Chromatic.MonochromaticLightnessStep
+	result := []formats.HSLA{base}
+
+	for n := 0; len(result) < 3 && n < monochromaticMaxSteps; n++ {
+		delta := step * float64(n/2+1)
+		if n%2 == 1 {
+			delta = -delta
+		}
+		l := base.L + delta
+		if l > monochromaticLightnessMax || l < monochromaticLightnessMin {
+			continue
+		}
+		variant := base
+		variant.L = l
+		result = append(result, variant)
+	}
+
+	for len(result) < 3 {
+		variant := base
+		variant.L = math.Max(monochromaticLightnessMin, math.Min(monochromaticLightnessMax, base.L))
+		result = append(result, variant)
+	}
+
+	return result
+}
+
+// rotations returns the base followed by copies rotated by each offset in
+// degrees, preserving saturation, lightness, and alpha.
+func rotations(base formats.HSLA, offsets ...float64) []formats.HSLA {
+	result := make([]formats.HSLA, 0, len(offsets)+1)
+	result = append(result, base)
+	for _, offset := range offsets {
+		rotated := base
+		rotated.H = math.Mod(math.Mod(base.H+offset, 360)+360, 360)
+		result = append(result, rotated)
+	}
+	return result
+}
+
+// WeightedHue is a hue in degrees with its relative prominence.
+type WeightedHue struct {
+	Hue    float64
+	Weight float64
+}
+
+// HarmonyMatch is the result of harmony detection.
+type HarmonyMatch struct {
+	Harmony    Harmony // Best matching harmony; empty when no hues were given
+	Base       float64 // Base hue in degrees the harmony is anchored on
+	Confidence float64 // Match quality from 0.0 to 1.0
+}
+
+// detectionOrder lists harmonies from simplest to most complex. A more
+// complex harmony must score strictly higher to be preferred.
+var detectionOrder = []Harmony{
+	HarmonyMonochromatic,
+	HarmonyComplementary,
+	HarmonyAnalogous,
+	HarmonySplitComplementary,
+	HarmonyTriadic,
+	HarmonyTetradic,
+}
+
+// DetectHarmony classifies the relationship between weighted hues. Every
+// harmony is anchored on each hue in turn and scored by how closely the hues
+// fall on its target hues, weighted by prominence, and by how many targets
+// are represented. Hues within the configured harmony hue tolerance of a
+// target count toward it, with fit falling off linearly with distance. A
+// tolerance of zero or less only counts hues exactly on a target.
+// Confidence is the product of weighted fit and target coverage.
+func (c *Chroma) DetectHarmony(hues []WeightedHue) HarmonyMatch {
+	var best HarmonyMatch
+	if len(hues) == 0 {
+		return best
+	}
+
+	for _, h := range detectionOrder {
+		offsets := c.harmonyOffsets(h)
+		for _, anchor := range hues {
+			for _, offset := range offsets {
+				base := math.Mod(anchor.Hue-offset+360, 360)
+				score := c.harmonyScore(hues, base, offsets)
+				if best.Harmony == "" || score > best.Confidence+1e-9 {
+					best = HarmonyMatch{Harmony: h, Base: base, Confidence: score}
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+// harmonyOffsets returns the distinct hue offsets of a harmony relative to
+// its base.
+func (c *Chroma) harmonyOffsets(h Harmony) []float64 {
+	colors, _ := c.Harmony(h, formats.NewHSL(0, 1, 0.5))
+
+	var offsets []float64
+	for _, color := range colors {
+		duplicate := false
+		for _, o := range offsets {
+			if hueDistance(o, color.H) < 1e-9 {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			offsets = append(offsets, color.H)
+		}
+	}
+	return offsets
+}
+
+// harmonyScore scores hues against the harmony targets at base.
+func (c *Chroma) harmonyScore(hues []WeightedHue, base float64, offsets []float64) float64 {
+	tolerance := c.settings.Chromatic.HarmonyHueTolerance
+	covered := make([]bool, len(offsets))
+
+	var fit, total float64
+	for _, wh := range hues {
+		total += wh.Weight
+
+		nearest, distance := 0, math.Inf(1)
+		for i, offset := range offsets {
+			if d := hueDistance(wh.Hue, math.Mod(base+offset, 360)); d < distance {
+				nearest, distance = i, d
+			}
+		}
+
+		switch {
+		case tolerance <= 0:
+			if distance < 1e-9 {
+				covered[nearest] = true
+				fit += wh.Weight
+			}
+		case distance <= tolerance:
+			covered[nearest] = true
+			fit += wh.Weight * (1 - distance/tolerance)
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	count := 0
+	for _, ok := range covered {
+		if ok {
+			count++
+		}
+	}
+
+	return (fit / total) * (float64(count) / float64(len(offsets)))
+}
@@ -0,0 +1,223 @@
+package chromatic
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// solverIterations bounds the binary search over LAB lightness.
+// 24 halvings of the 0-100 L* range resolve well below one 8-bit step.
+const solverIterations = 24
+
+// ContrastPair names a foreground/background combination and the
+// accessibility level it must satisfy.
+type ContrastPair struct {
+	Name       string
+	Foreground color.RGBA
+	Background color.RGBA
+	Level      AccessibilityLevel
+}
+
+// ContrastAdjustment records how a pair's foreground was moved to satisfy
+// its accessibility level.
+type ContrastAdjustment struct {
+	Name           string
+	Level          AccessibilityLevel
+	Original       color.RGBA // Foreground before adjustment
+	Adjusted       color.RGBA // Foreground after adjustment
+	Background     color.RGBA // Background the foreground was measured against
+	OriginalRatio  float64    // Contrast ratio before adjustment
+	AdjustedRatio  float64    // Contrast ratio after adjustment
+	OriginalLc     float64    // APCA lightness contrast before adjustment
+	AdjustedLc     float64    // APCA lightness contrast after adjustment
+	LightnessDelta float64    // Change in LAB L* (positive is lighter)
+	Satisfied      bool       // True if AdjustedRatio meets the level
+}
+
+// Moved reports whether the foreground was changed.
+func (a ContrastAdjustment) Moved() bool {
+	return a.Original != a.Adjusted
+}
+
+// MeetsAPCA reports whether the adjusted foreground meets the APCA level
+// against the background.
+func (a ContrastAdjustment) MeetsAPCA(level APCALevel) bool {
+	return math.Abs(a.AdjustedLc) >= level.Lc()
+}
+
+// ContrastReport summarizes the adjustments made across a set of pairs.
+type ContrastReport struct {
+	Adjustments []ContrastAdjustment
+}
+
+// Find returns the adjustment for the named pair.
+func (r ContrastReport) Find(name string) (ContrastAdjustment, bool) {
+	for _, a := range r.Adjustments {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return ContrastAdjustment{}, false
+}
+
+// BelowAPCA returns the adjustments whose adjusted foreground does not meet
+// the APCA level. Pairs can satisfy their WCAG 2 ratio and still fall below
+// an APCA level, most often light text on dark backgrounds.
+func (r ContrastReport) BelowAPCA(level APCALevel) []ContrastAdjustment {
+	var below []ContrastAdjustment
+	for _, a := range r.Adjustments {
+		if !a.MeetsAPCA(level) {
+			below = append(below, a)
+		}
+	}
+	return below
+}
+
+// Moved returns the adjustments whose foreground was changed.
+func (r ContrastReport) Moved() []ContrastAdjustment {
+	var moved []ContrastAdjustment
+	for _, a := range r.Adjustments {
+		if a.Moved() {
+			moved = append(moved, a)
+		}
+	}
+	return moved
+}
+
+// Failures returns the adjustments that could not satisfy their level.
+func (r ContrastReport) Failures() []ContrastAdjustment {
+	var failures []ContrastAdjustment
+	for _, a := range r.Adjustments {
+		if !a.Satisfied {
+			failures = append(failures, a)
+		}
+	}
+	return failures
+}
+
+// EnforceContrast adjusts the lightness of fg until its contrast ratio with bg
+// meets the accessibility level. Lightness is searched in LAB space with the
+// a* and b* channels held fixed to preserve hue. The search first moves fg away
+// from bg in its current direction (lighter foregrounds get lighter, darker get
+// darker) and falls back to the opposite direction when that side of the
+// lightness scale cannot reach the target. The smallest passing change is
+// returned; if neither direction passes, the extreme with the highest ratio is
+// returned with Satisfied set to false.
+func EnforceContrast(fg, bg color.RGBA, level AccessibilityLevel) ContrastAdjustment {
+	target := level.Ratio()
+	ratio := ContrastRatio(fg, bg)
+
+	adjustment := ContrastAdjustment{
+		Level:         level,
+		Original:      fg,
+		Adjusted:      fg,
+		Background:    bg,
+		OriginalRatio: ratio,
+		AdjustedRatio: ratio,
+		OriginalLc:    APCAContrast(fg, bg),
+		Satisfied:     ratio >= target,
+	}
+	adjustment.AdjustedLc = adjustment.OriginalLc
+
+	if adjustment.Satisfied {
+		return adjustment
+	}
+
+	lab := formats.RGBAToLAB(fg)
+	lighter := Luminance(fg) >= Luminance(bg)
+
+	best, found := searchLightness(lab, fg.A, bg, target, lighter)
+	if !found {
+		best, found = searchLightness(lab, fg.A, bg, target, !lighter)
+	}
+	if !found {
+		toWhite := extremeLightness(lab, fg.A, true)
+		toBlack := extremeLightness(lab, fg.A, false)
+		best = toBlack
+		if ContrastRatio(toWhite, bg) > ContrastRatio(toBlack, bg) {
+			best = toWhite
+		}
+	}
+
+	adjustment.Adjusted = best
+	adjustment.AdjustedRatio = ContrastRatio(best, bg)
+	adjustment.AdjustedLc = APCAContrast(best, bg)
+	adjustment.LightnessDelta = formats.RGBAToLAB(best).L - lab.L
+	adjustment.Satisfied = found
+
+	return adjustment
+}
+
+// EnforceContrastPairs applies EnforceContrast to every pair and returns the
+// pairs with adjusted foregrounds alongside a report of what moved.
+// Pairs without a level default to AA.
+func EnforceContrastPairs(pairs []ContrastPair) ([]ContrastPair, ContrastReport) {
+	adjusted := make([]ContrastPair, len(pairs))
+	report := ContrastReport{
+		Adjustments: make([]ContrastAdjustment, len(pairs)),
+	}
+
+	for i, pair := range pairs {
+		level := pair.Level
+		if level == "" {
+			level = AA
+		}
+
+		a := EnforceContrast(pair.Foreground, pair.Background, level)
+		a.Name = pair.Name
+
+		adjusted[i] = pair
+		adjusted[i].Foreground = a.Adjusted
+		adjusted[i].Level = level
+		report.Adjustments[i] = a
+	}
+
+	return adjusted, report
+}
+
+// searchLightness binary searches LAB lightness between the current value and
+// the lighter or darker extreme for the smallest change meeting target.
+func searchLightness(lab formats.LAB, alpha uint8, bg color.RGBA, target float64, lighter bool) (color.RGBA, bool) {
+	extreme := extremeLightness(lab, alpha, lighter)
+	if ContrastRatio(extreme, bg) < target {
+		return color.RGBA{}, false
+	}
+
+	lo, hi := lab.L, 0.0
+	if lighter {
+		hi = 100.0
+	}
+
+	best := extreme
+	for i := 0; i < solverIterations; i++ {
+		mid := (lo + hi) / 2
+		candidate := withLightness(lab, mid, alpha)
+		if ContrastRatio(candidate, bg) >= target {
+			best = candidate
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return best, true
+}
+
+// extremeLightness returns the color at the lightest or darkest end of the
+// LAB lightness scale with chroma preserved.
+func extremeLightness(lab formats.LAB, alpha uint8, lighter bool) color.RGBA {
+	if lighter {
+		return withLightness(lab, 100, alpha)
+	}
+	return withLightness(lab, 0, alpha)
+}
+
+// withLightness converts lab to RGBA with L* replaced and alpha preserved.
+func withLightness(lab formats.LAB, l float64, alpha uint8) color.RGBA {
+	lab.L = math.Max(0, math.Min(100, l))
+	c := formats.LABToRGBA(lab)
+	c.A = alpha
+	return c
+}
@@ -37,7 +37,7 @@
 //
 // Errors are organized by functional domain:
 //   - extractor.go: Image loading, color extraction, and analysis errors
-//   - palette.go: Color palette generation and synthesis errors (Session 4+)
-//   - theme.go: Theme generation and template errors (Session 5+)
+//   - palette.go: Semantic palette generation and role assignment errors
+//   - theme.go: Theme directory generation, template, and metadata errors
 //   - template.go: Configuration file generation errors (Session 6+)
 package errors
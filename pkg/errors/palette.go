@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Palette sentinel errors for semantic role assignment failures.
+// These errors can be checked using errors.Is() for programmatic handling.
+var (
+	// ErrEmptyProfile indicates the color profile is nil or contains no color clusters.
+	ErrEmptyProfile = errors.New("color profile contains no colors")
+
+	// ErrInvalidFallback indicates a configured fallback color could not be parsed.
+	ErrInvalidFallback = errors.New("invalid fallback color")
+)
+
+// PaletteError represents a failure to assign a semantic role during palette generation.
+// It identifies the role being assigned and preserves the underlying cause.
+type PaletteError struct {
+	Role    string // Semantic role being assigned (e.g., "background", "primary")
+	Details string // Human-readable details about the failure
+	Err     error  // Underlying error that caused the failure
+}
+
+// Error returns a human-readable description of the role assignment failure.
+func (e *PaletteError) Error() string {
+	return fmt.Sprintf("palette role %s: %s: %v", e.Role, e.Details, e.Err)
+}
+
+// Unwrap returns the underlying error for use with errors.Is() and errors.As().
+func (e *PaletteError) Unwrap() error {
+	return e.Err
+}
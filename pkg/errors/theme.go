@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Theme sentinel errors for theme directory generation failures.
+// These errors can be checked using errors.Is() for programmatic handling.
+var (
+	// ErrInvalidThemeName indicates the theme name is empty or not a single path element.
+	ErrInvalidThemeName = errors.New("invalid theme name")
+
+	// ErrThemeExists indicates a theme with the same name already exists.
+	ErrThemeExists = errors.New("theme already exists")
+
+	// ErrThemeNotFound indicates the requested theme directory does not exist.
+	ErrThemeNotFound = errors.New("theme not found")
+)
+
+// ThemeWriteError wraps failures while generating a theme directory with the
+// theme name, the path being written, and the operation that failed.
+type ThemeWriteError struct {
+	Theme     string // Theme name being generated
+	Path      string // File or directory path involved in the failure
+	Operation string // Operation that failed (e.g., "render", "copy", "commit")
+	Err       error  // Underlying error that caused the failure
+}
+
+// Error returns a human-readable description of the write failure.
+func (e *ThemeWriteError) Error() string {
+	return fmt.Sprintf("theme %s: failed to %s %s: %v", e.Theme, e.Operation, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error for use with errors.Is() and errors.As().
+func (e *ThemeWriteError) Unwrap() error {
+	return e.Err
+}
+
+// Metadata sentinel errors for theme-gen.json failures.
+var (
+	// ErrInvalidMetadata indicates theme-gen.json is malformed or missing required data.
+	ErrInvalidMetadata = errors.New("invalid theme metadata")
+
+	// ErrUnsupportedMetadataVersion indicates theme-gen.json has a version with no migration path.
+	ErrUnsupportedMetadataVersion = errors.New("unsupported theme metadata version")
+)
+
+// MetadataError wraps failures while reading or migrating theme-gen.json with
+// the file path and the schema version found in the file.
+type MetadataError struct {
+	Path    string // Path to the metadata file
+	Version string // Schema version found in the file, empty if unknown
+	Details string // Description of the failure
+	Err     error  // Underlying error that caused the failure
+}
+
+// Error returns a human-readable description of the metadata failure.
+func (e *MetadataError) Error() string {
+	if e.Version != "" {
+		return fmt.Sprintf("theme metadata %s (version %s): %s: %v", e.Path, e.Version, e.Details, e.Err)
+	}
+	return fmt.Sprintf("theme metadata %s: %s: %v", e.Path, e.Details, e.Err)
+}
+
+// Unwrap returns the underlying error for use with errors.Is() and errors.As().
+func (e *MetadataError) Unwrap() error {
+	return e.Err
+}
@@ -211,6 +211,84 @@ func XYZToRGBA(xyz XYZ) color.RGBA {
 	}
 }
 
+// RGBAToOKLab converts a color.RGBA to the OKLab color space.
+// Alpha is ignored.
+func RGBAToOKLab(c color.RGBA) OKLab {
+	r := inverseSRGBGamma(float64(c.R) / 255.0)
+	g := inverseSRGBGamma(float64(c.G) / 255.0)
+	b := inverseSRGBGamma(float64(c.B) / 255.0)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// OKLabToRGBA converts an OKLab color to an opaque color.RGBA.
+// Out-of-gamut channels are clipped to [0-255].
+func OKLabToRGBA(c OKLab) color.RGBA {
+	r, g, b := oklabToLinearRGB(c)
+	return color.RGBA{
+		R: uint8(math.Round(clamp(sRGBGamma(r), 0, 1) * 255)),
+		G: uint8(math.Round(clamp(sRGBGamma(g), 0, 1) * 255)),
+		B: uint8(math.Round(clamp(sRGBGamma(b), 0, 1) * 255)),
+		A: 255,
+	}
+}
+
+// OKLabToOKLCH converts OKLab to its cylindrical form. Achromatic colors
+// have hue 0.
+func OKLabToOKLCH(c OKLab) OKLCH {
+	chroma := math.Hypot(c.A, c.B)
+	if chroma < 1e-6 {
+		return OKLCH{L: c.L, C: 0, H: 0}
+	}
+
+	h := math.Atan2(c.B, c.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCH{L: c.L, C: chroma, H: h}
+}
+
+// OKLCHToOKLab converts OKLCH to rectangular OKLab.
+func OKLCHToOKLab(c OKLCH) OKLab {
+	rad := c.H * math.Pi / 180
+	return OKLab{L: c.L, A: c.C * math.Cos(rad), B: c.C * math.Sin(rad)}
+}
+
+// RGBAToOKLCH converts a color.RGBA to the OKLCH color space.
+// Alpha is ignored.
+func RGBAToOKLCH(c color.RGBA) OKLCH {
+	return OKLabToOKLCH(RGBAToOKLab(c))
+}
+
+// OKLCHToRGBA converts an OKLCH color to an opaque color.RGBA. Colors outside
+// the sRGB gamut are mapped in by reducing chroma at constant lightness and
+// hue, so lightness ramps and hue rotations stay perceptually even.
+func OKLCHToRGBA(c OKLCH) color.RGBA {
+	if c.InGamut() {
+		return OKLabToRGBA(OKLCHToOKLab(c))
+	}
+
+	low, high := 0.0, c.C
+	for i := 0; i < 24; i++ {
+		mid := (low + high) / 2
+		if (OKLCH{L: c.L, C: mid, H: c.H}).InGamut() {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return OKLabToRGBA(OKLCHToOKLab(OKLCH{L: c.L, C: low, H: c.H}))
+}
+
 // clamp constrains a value to the specified range [min, max].
 // Used throughout the package to ensure color component values stay within valid bounds.
 func clamp(value, min, max float64) float64 {
@@ -247,6 +325,16 @@ func inverseLABTransform(t float64) float64 {
 	return 3 * (6.0 / 29.0) * (6.0 / 29.0) * (t - 4.0/29.0)
 }
 
+// gamutEpsilon tolerates floating point error at the sRGB gamut boundary.
+const gamutEpsilon = 1e-4
+
+// inSRGBGamut reports whether linear RGB channels lie within [0-1].
+func inSRGBGamut(r, g, b float64) bool {
+	return r >= -gamutEpsilon && r <= 1+gamutEpsilon &&
+		g >= -gamutEpsilon && g <= 1+gamutEpsilon &&
+		b >= -gamutEpsilon && b <= 1+gamutEpsilon
+}
+
 func inverseSRGBGamma(value float64) float64 {
 	if value <= 0.04045 {
 		return value / 12.92
@@ -254,6 +342,20 @@ func inverseSRGBGamma(value float64) float64 {
 	return math.Pow((value+0.055)/1.055, 2.4)
 }
 
+// oklabToLinearRGB converts OKLab to unclipped linear sRGB channels.
+func oklabToLinearRGB(c OKLab) (r, g, b float64) {
+	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
+	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
+	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r = 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g = -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b = -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+	return
+}
+
 func labTransform(t float64) float64 {
 	if t > 0.008856 {
 		return math.Pow(t, 1.0/3.0)
@@ -1,5 +1,12 @@
 // Package formats provides color format conversions and utilities using
 // standard library types. All functions operate on color.RGBA values from
 // the Go standard library, providing a functional interface for color
-// space conversions, accessibility calculations, and format transformations.
+// space conversions, accessibility calculations, and format transformations,
+// including the per-component color encodings used by Omarchy theme files.
+//
+// Supported color spaces are RGBA, HSLA, XYZ, CIE LAB, and OKLab with its
+// cylindrical OKLCH form. OKLCH lightness is perceptually uniform across
+// hues, unlike HSL lightness, making it the better space for lightness ramps
+// and hue rotation; conversions from OKLCH map out-of-gamut colors into sRGB
+// by reducing chroma.
 package formats
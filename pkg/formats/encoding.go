@@ -0,0 +1,185 @@
+package formats
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ToHexLower converts a color.RGBA to a lowercase hex color string in the
+// format #rrggbb, as used by alacritty, btop, mako, neovim, and CSS files.
+// Alpha channel is ignored.
+func ToHexLower(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// ToBareHex converts a color.RGBA to a lowercase hex string without the
+// leading # in the format rrggbb. Alpha channel is ignored.
+func ToBareHex(c color.RGBA) string {
+	return fmt.Sprintf("%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// ToQuotedHex converts a color.RGBA to a double-quoted lowercase hex string
+// in the format "#rrggbb" for TOML and Lua string values.
+func ToQuotedHex(c color.RGBA) string {
+	return strconv.Quote(ToHexLower(c))
+}
+
+// ToHyprRGB converts a color.RGBA to the hyprland rgb() function in the
+// format rgb(rrggbb). Alpha channel is ignored.
+func ToHyprRGB(c color.RGBA) string {
+	return fmt.Sprintf("rgb(%02x%02x%02x)", c.R, c.G, c.B)
+}
+
+// ToHyprRGBA converts a color.RGBA to the hyprland rgba() function in the
+// format rgba(rrggbbaa).
+func ToHyprRGBA(c color.RGBA) string {
+	return fmt.Sprintf("rgba(%02x%02x%02x%02x)", c.R, c.G, c.B, c.A)
+}
+
+// ToDecimalRGBA converts a color.RGBA to the decimal rgba() function used by
+// hyprlock in the format rgba(36, 39, 58, 1.0). Alpha is written as a
+// 0.0-1.0 value rounded to two decimal places with at least one decimal.
+func ToDecimalRGBA(c color.RGBA) string {
+	return fmt.Sprintf("rgba(%d, %d, %d, %s)", c.R, c.G, c.B, formatAlpha(GetAlpha(c)))
+}
+
+// ToChromiumRGB converts a color.RGBA to the comma-separated decimal format
+// used by chromium.theme in the format 239,241,245. Alpha channel is ignored.
+func ToChromiumRGB(c color.RGBA) string {
+	return fmt.Sprintf("%d,%d,%d", c.R, c.G, c.B)
+}
+
+// ParseQuotedHex parses a double- or single-quoted hex color string such as
+// "#24273a". The quoted value accepts the same formats as ParseHex.
+func ParseQuotedHex(s string) (color.RGBA, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != s[len(s)-1] || (s[0] != '"' && s[0] != '\'') {
+		return color.RGBA{}, fmt.Errorf("invalid quoted hex color: %s", s)
+	}
+	return ParseHex(s[1 : len(s)-1])
+}
+
+// ParseHyprColor parses the hyprland rgb(rrggbb) and rgba(rrggbbaa) functions.
+// Colors parsed from rgb() have alpha 255.
+func ParseHyprColor(s string) (color.RGBA, error) {
+	name, args, err := splitFunction(s)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+
+	switch {
+	case name == "rgb" && len(args) == 6:
+	case name == "rgba" && len(args) == 8:
+	default:
+		return color.RGBA{}, fmt.Errorf("invalid hyprland color: %s", s)
+	}
+
+	return ParseHex(args)
+}
+
+// ParseDecimalRGBA parses the decimal rgba(r, g, b, a) and rgb(r, g, b)
+// functions used by hyprlock. Channels must be integers in 0-255 and alpha a
+// value in 0.0-1.0.
+func ParseDecimalRGBA(s string) (color.RGBA, error) {
+	name, args, err := splitFunction(s)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+
+	parts := strings.Split(args, ",")
+	switch {
+	case name == "rgb" && len(parts) == 3:
+	case name == "rgba" && len(parts) == 4:
+	default:
+		return color.RGBA{}, fmt.Errorf("invalid decimal rgba color: %s", s)
+	}
+
+	c, err := parseChannels(parts[:3])
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid decimal rgba color %s: %w", s, err)
+	}
+
+	if len(parts) == 4 {
+		alpha, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err != nil || alpha < 0 || alpha > 1 {
+			return color.RGBA{}, fmt.Errorf("invalid alpha in %s: %s", s, strings.TrimSpace(parts[3]))
+		}
+		c = WithAlpha(c, alpha)
+	}
+
+	return c, nil
+}
+
+// ParseChromiumRGB parses the comma-separated decimal format r,g,b used by
+// chromium.theme. Whitespace around channels is ignored.
+func ParseChromiumRGB(s string) (color.RGBA, error) {
+	parts := strings.Split(strings.TrimSpace(s), ",")
+	if len(parts) != 3 {
+		return color.RGBA{}, fmt.Errorf("invalid chromium color: %s", s)
+	}
+
+	c, err := parseChannels(parts)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid chromium color %s: %w", s, err)
+	}
+	return c, nil
+}
+
+// ParseColor parses a color in any of the component encodings written to
+// theme files: hex with or without #, quoted hex, hyprland rgb()/rgba(),
+// decimal rgba(), and chromium r,g,b. The format is detected from the input.
+func ParseColor(s string) (color.RGBA, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'"):
+		return ParseQuotedHex(s)
+	case strings.HasPrefix(s, "rgb"):
+		if strings.Contains(s, ",") {
+			return ParseDecimalRGBA(s)
+		}
+		return ParseHyprColor(s)
+	case strings.Contains(s, ","):
+		return ParseChromiumRGB(s)
+	default:
+		return ParseHex(s)
+	}
+}
+
+// splitFunction splits a CSS-style function call such as rgb(...) into its
+// name and argument text with surrounding whitespace removed.
+func splitFunction(s string) (string, string, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '(')
+	if open <= 0 || !strings.HasSuffix(s, ")") {
+		return "", "", fmt.Errorf("invalid color function: %s", s)
+	}
+	return strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+1 : len(s)-1]), nil
+}
+
+// parseChannels parses three decimal 0-255 channel values into an opaque color.
+func parseChannels(parts []string) (color.RGBA, error) {
+	var channels [3]uint8
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || v < 0 || v > 255 {
+			return color.RGBA{}, fmt.Errorf("channel out of range: %s", strings.TrimSpace(part))
+		}
+		channels[i] = uint8(v)
+	}
+	return color.RGBA{R: channels[0], G: channels[1], B: channels[2], A: 255}, nil
+}
+
+// formatAlpha formats an alpha value rounded to two decimal places, trimming
+// trailing zeros but keeping at least one decimal (1.0, 0.8, 0.93).
+func formatAlpha(alpha float64) string {
+	rounded := math.Round(alpha*100) / 100
+	s := strconv.FormatFloat(rounded, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
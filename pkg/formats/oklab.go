@@ -0,0 +1,88 @@
+package formats
+
+import (
+	"fmt"
+	"math"
+)
+
+// OKLab represents a color in the OKLab perceptual color space.
+// L is perceived lightness [0-1]; A (green-red) and B (blue-yellow) are
+// opponent axes, roughly [-0.4, 0.4] for sRGB colors.
+type OKLab struct {
+	L float64
+	A float64
+	B float64
+}
+
+// OKLCH represents OKLab in cylindrical form.
+// L is perceived lightness [0-1], C is chroma [0, ~0.4] for sRGB colors,
+// and H is hue in degrees [0-360).
+type OKLCH struct {
+	L float64
+	C float64
+	H float64
+}
+
+// RGBA converts OKLab to the color.Color interface.
+// Out-of-gamut colors are clipped per channel.
+func (c OKLab) RGBA() (r, g, b, a uint32) {
+	rgba := OKLabToRGBA(c)
+	r = uint32(rgba.R) * 0x101
+	g = uint32(rgba.G) * 0x101
+	b = uint32(rgba.B) * 0x101
+	a = uint32(rgba.A) * 0x101
+	return
+}
+
+// RGBA converts OKLCH to the color.Color interface.
+// Out-of-gamut colors are mapped into sRGB by reducing chroma.
+func (c OKLCH) RGBA() (r, g, b, a uint32) {
+	rgba := OKLCHToRGBA(c)
+	r = uint32(rgba.R) * 0x101
+	g = uint32(rgba.G) * 0x101
+	b = uint32(rgba.B) * 0x101
+	a = uint32(rgba.A) * 0x101
+	return
+}
+
+func NewOKLab(l, a, b float64) OKLab {
+	return OKLab{L: l, A: a, B: b}
+}
+
+// NewOKLCH creates an OKLCH color with hue normalized to [0-360) and
+// lightness and chroma clamped to their non-negative ranges.
+func NewOKLCH(l, c, h float64) OKLCH {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return OKLCH{L: clamp(l, 0, 1), C: math.Max(c, 0), H: h}
+}
+
+// InGamut reports whether the color is representable in sRGB without clipping.
+func (c OKLab) InGamut() bool {
+	return inSRGBGamut(oklabToLinearRGB(c))
+}
+
+// InGamut reports whether the color is representable in sRGB without clipping.
+func (c OKLCH) InGamut() bool {
+	return OKLCHToOKLab(c).InGamut()
+}
+
+// WithLightness returns a copy with lightness clamped to [0-1].
+func (c OKLCH) WithLightness(l float64) OKLCH {
+	return NewOKLCH(l, c.C, c.H)
+}
+
+// Rotate returns a copy with the hue rotated by the given degrees.
+func (c OKLCH) Rotate(degrees float64) OKLCH {
+	return NewOKLCH(c.L, c.C, c.H+degrees)
+}
+
+func (c OKLab) String() string {
+	return fmt.Sprintf("OKLab(%.4f, %.4f, %.4f)", c.L, c.A, c.B)
+}
+
+func (c OKLCH) String() string {
+	return fmt.Sprintf("OKLCH(%.4f, %.4f, %.2f)", c.L, c.C, c.H)
+}
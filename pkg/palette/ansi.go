@@ -0,0 +1,220 @@
+package palette
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+)
+
+// ANSISlot identifies one of the six chromatic ANSI terminal colors.
+type ANSISlot int
+
+const (
+	ANSIRed ANSISlot = iota
+	ANSIYellow
+	ANSIGreen
+	ANSICyan
+	ANSIBlue
+	ANSIMagenta
+)
+
+// ANSISlots lists the chromatic ANSI slots in hue order.
+var ANSISlots = []ANSISlot{ANSIRed, ANSIYellow, ANSIGreen, ANSICyan, ANSIBlue, ANSIMagenta}
+
+// Hue returns the canonical hue in degrees for the slot.
+func (s ANSISlot) Hue() float64 {
+	return float64(s) * 60
+}
+
+// String returns the lowercase ANSI color name for the slot.
+func (s ANSISlot) String() string {
+	switch s {
+	case ANSIRed:
+		return "red"
+	case ANSIYellow:
+		return "yellow"
+	case ANSIGreen:
+		return "green"
+	case ANSICyan:
+		return "cyan"
+	case ANSIBlue:
+		return "blue"
+	case ANSIMagenta:
+		return "magenta"
+	default:
+		return "unknown"
+	}
+}
+
+// ANSIColors holds one intensity row of the ANSI terminal palette.
+type ANSIColors struct {
+	Black   color.RGBA
+	Red     color.RGBA
+	Green   color.RGBA
+	Yellow  color.RGBA
+	Blue    color.RGBA
+	Magenta color.RGBA
+	Cyan    color.RGBA
+	White   color.RGBA
+}
+
+// ANSIPalette is the complete 16-color terminal palette.
+type ANSIPalette struct {
+	Normal ANSIColors // ANSI 0-7
+	Bright ANSIColors // ANSI 8-15
+}
+
+// Colors returns the palette in ANSI index order (0-15).
+func (p ANSIPalette) Colors() [16]color.RGBA {
+	return [16]color.RGBA{
+		p.Normal.Black, p.Normal.Red, p.Normal.Green, p.Normal.Yellow,
+		p.Normal.Blue, p.Normal.Magenta, p.Normal.Cyan, p.Normal.White,
+		p.Bright.Black, p.Bright.Red, p.Bright.Green, p.Bright.Yellow,
+		p.Bright.Blue, p.Bright.Magenta, p.Bright.Cyan, p.Bright.White,
+	}
+}
+
+// set assigns the color for a chromatic slot.
+func (c *ANSIColors) set(slot ANSISlot, value color.RGBA) {
+	switch slot {
+	case ANSIRed:
+		c.Red = value
+	case ANSIYellow:
+		c.Yellow = value
+	case ANSIGreen:
+		c.Green = value
+	case ANSICyan:
+		c.Cyan = value
+	case ANSIBlue:
+		c.Blue = value
+	case ANSIMagenta:
+		c.Magenta = value
+	}
+}
+
+// BuildANSI generates the 16-color terminal palette for a ColorProfile using
+// the same background and foreground selection as Build.
+func (g *Generator) BuildANSI(profile *processor.ColorProfile) (ANSIPalette, error) {
+	pal, err := g.Build(profile)
+	if err != nil {
+		return ANSIPalette{}, err
+	}
+	return pal.Terminal, nil
+}
+
+// buildANSI snaps ANSI source clusters to the nearest canonical ANSI hue slot
+// and keeps the heaviest cluster per slot. Slots without a cluster within
+// the configured hue tolerance are synthesized at the canonical hue using
+// the weighted average saturation and lightness of the image. Black and
+// white rows are blended between the background and foreground.
+func (g *Generator) buildANSI(profile *processor.ColorProfile, bg, fg color.RGBA) ANSIPalette {
+	var slots [6]*processor.ColorCluster
+	for i := range profile.Colors {
+		cluster := &profile.Colors[i]
+		if !isANSISource(*cluster) {
+			continue
+		}
+
+		slot, distance := nearestSlot(cluster.Hue)
+		if distance > g.settings.Palette.ANSIHueTolerance {
+			continue
+		}
+		if slots[slot] == nil || cluster.Weight > slots[slot].Weight {
+			slots[slot] = cluster
+		}
+	}
+
+	avgS, avgL := g.averageChroma(profile)
+
+	var terminal ANSIPalette
+	for _, slot := range ANSISlots {
+		hue, s, l := slot.Hue(), avgS, avgL
+		if cluster := slots[slot]; cluster != nil {
+			hue, s, l = cluster.Hue, cluster.Saturation, cluster.Lightness
+		}
+
+		normal := g.normalizeANSI(profile.Mode, hue, s, l)
+		bright := normal
+		bright.L = math.Min(normal.L+g.settings.Palette.ANSIBrightLightnessDelta, 0.9)
+
+		terminal.Normal.set(slot, formats.HSLAToRGBA(normal))
+		terminal.Bright.set(slot, formats.HSLAToRGBA(bright))
+	}
+
+	darkEnd, lightEnd := bg, fg
+	if profile.Mode == processor.Light {
+		darkEnd, lightEnd = fg, bg
+	}
+
+	terminal.Normal.Black = mix(darkEnd, lightEnd, 0.2)
+	terminal.Bright.Black = mix(darkEnd, lightEnd, 0.35)
+	terminal.Normal.White = mix(lightEnd, darkEnd, 0.2)
+	terminal.Bright.White = lightEnd
+
+	return terminal
+}
+
+// isANSISource reports whether a cluster can supply an ANSI chromatic color.
+// Neutral clusters and muted surface tints (muted clusters at either end of
+// the lightness scale) are excluded so backgrounds and text colors do not
+// claim hue slots.
+func isANSISource(c processor.ColorCluster) bool {
+	if c.IsNeutral {
+		return false
+	}
+	return !(c.IsMuted && (c.IsDark || c.IsLight))
+}
+
+// averageChroma returns the weighted average saturation and lightness of the
+// ANSI source clusters in the profile. Grayscale profiles fall back to the
+// configured minimum saturation and the midpoint of the lightness range.
+func (g *Generator) averageChroma(profile *processor.ColorProfile) (float64, float64) {
+	var sumS, sumL, total float64
+	for _, cluster := range profile.Colors {
+		if !isANSISource(cluster) {
+			continue
+		}
+		sumS += cluster.Saturation * cluster.Weight
+		sumL += cluster.Lightness * cluster.Weight
+		total += cluster.Weight
+	}
+
+	if total == 0 {
+		p := g.settings.Palette
+		return p.ANSISaturationMin, (p.ANSILightnessMin + p.ANSILightnessMax) / 2
+	}
+
+	return sumS / total, sumL / total
+}
+
+// normalizeANSI clamps saturation and lightness into the configured ANSI
+// ranges so every slot remains recognizable and legible. Light themes mirror
+// the lightness range toward the dark end of the scale.
+func (g *Generator) normalizeANSI(mode processor.ThemeMode, hue, saturation, lightness float64) formats.HSLA {
+	p := g.settings.Palette
+	minL, maxL := p.ANSILightnessMin, p.ANSILightnessMax
+	if mode == processor.Light {
+		minL, maxL = 1-p.ANSILightnessMax, 1-p.ANSILightnessMin
+	}
+
+	return formats.NewHSL(
+		hue,
+		math.Max(saturation, p.ANSISaturationMin),
+		math.Max(minL, math.Min(lightness, maxL)),
+	)
+}
+
+// nearestSlot returns the ANSI slot whose canonical hue is closest to hue,
+// along with the angular distance in degrees.
+func nearestSlot(hue float64) (ANSISlot, float64) {
+	best := ANSIRed
+	bestDistance := math.MaxFloat64
+	for _, slot := range ANSISlots {
+		if d := hueDistance(hue, slot.Hue()); d < bestDistance {
+			best, bestDistance = slot, d
+		}
+	}
+	return best, bestDistance
+}
@@ -0,0 +1,102 @@
+package palette
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+)
+
+// enforceContrast repairs foreground roles that fail their configured WCAG
+// level against the background. Foreground text uses the text contrast level;
+// dim foreground, accents, and terminal chromatic colors use the accent level.
+// Pinned roles are left unchanged and omitted from the report. Roles derived
+// from the primary accent are refreshed after adjustment, and the border is
+// then held to the border contrast level unless it follows a pinned primary.
+// Unknown contrast level names are an error.
+func (g *Generator) enforceContrast(pal *SemanticPalette, pinned map[string]bool) error {
+	text, err := contrastLevel("text_contrast_level", g.settings.Palette.TextContrastLevel)
+	if err != nil {
+		return err
+	}
+	accent, err := contrastLevel("accent_contrast_level", g.settings.Palette.AccentContrastLevel)
+	if err != nil {
+		return err
+	}
+	border, err := contrastLevel("border_contrast_level", g.settings.Palette.BorderContrastLevel)
+	if err != nil {
+		return err
+	}
+
+	all := []struct {
+		name  string
+		color *color.RGBA
+		level chromatic.AccessibilityLevel
+	}{
+		{"foreground", &pal.Foreground, text},
+		{"dim_foreground", &pal.DimForeground, accent},
+		{"primary", &pal.Primary, accent},
+		{"secondary", &pal.Secondary, accent},
+		{"tertiary", &pal.Tertiary, accent},
+		{"normal.red", &pal.Terminal.Normal.Red, accent},
+		{"normal.green", &pal.Terminal.Normal.Green, accent},
+		{"normal.yellow", &pal.Terminal.Normal.Yellow, accent},
+		{"normal.blue", &pal.Terminal.Normal.Blue, accent},
+		{"normal.magenta", &pal.Terminal.Normal.Magenta, accent},
+		{"normal.cyan", &pal.Terminal.Normal.Cyan, accent},
+		{"normal.white", &pal.Terminal.Normal.White, accent},
+		{"bright.red", &pal.Terminal.Bright.Red, accent},
+		{"bright.green", &pal.Terminal.Bright.Green, accent},
+		{"bright.yellow", &pal.Terminal.Bright.Yellow, accent},
+		{"bright.blue", &pal.Terminal.Bright.Blue, accent},
+		{"bright.magenta", &pal.Terminal.Bright.Magenta, accent},
+		{"bright.cyan", &pal.Terminal.Bright.Cyan, accent},
+		{"bright.white", &pal.Terminal.Bright.White, text},
+	}
+
+	targets := all[:0]
+	for _, t := range all {
+		if !pinned[t.name] {
+			targets = append(targets, t)
+		}
+	}
+
+	pairs := make([]chromatic.ContrastPair, len(targets))
+	for i, t := range targets {
+		pairs[i] = chromatic.ContrastPair{
+			Name:       t.name,
+			Foreground: *t.color,
+			Background: pal.Background,
+			Level:      t.level,
+		}
+	}
+
+	adjusted, report := chromatic.EnforceContrastPairs(pairs)
+	for i, t := range targets {
+		*t.color = adjusted[i].Foreground
+	}
+
+	pal.Cursor = pal.Primary
+	pal.Border = pal.Primary
+	pal.Selection = mix(pal.Primary, pal.Background, g.settings.Palette.SelectionMix)
+
+	if !pinned["primary"] {
+		a := chromatic.EnforceContrast(pal.Border, pal.Background, border)
+		a.Name = "border"
+		pal.Border = a.Adjusted
+		report.Adjustments = append(report.Adjustments, a)
+	}
+
+	pal.Contrast = report
+	return nil
+}
+
+// contrastLevel parses the accessibility level configured under the given
+// palette setting key.
+func contrastLevel(key, name string) (chromatic.AccessibilityLevel, error) {
+	level, err := chromatic.ParseAccessibilityLevel(name)
+	if err != nil {
+		return "", fmt.Errorf("palette.%s: %w", key, err)
+	}
+	return level, nil
+}
@@ -0,0 +1,40 @@
+package palette
+
+import "github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+
+// checkDeficiencies reports the color pairs that collapse under simulated
+// color vision deficiencies. Colors are compared within the groups a user
+// tells apart side by side: the normal ANSI chromatic colors, the bright ANSI
+// chromatic colors, and the accents. Black and white are left out because
+// they differ from the rest in lightness, which every deficiency preserves.
+func (g *Generator) checkDeficiencies(pal *SemanticPalette) chromatic.DeficiencyReport {
+	groups := [][]chromatic.NamedColor{
+		ansiChromatic("normal", pal.Terminal.Normal),
+		ansiChromatic("bright", pal.Terminal.Bright),
+		{
+			{Name: "primary", Color: pal.Primary},
+			{Name: "secondary", Color: pal.Secondary},
+			{Name: "tertiary", Color: pal.Tertiary},
+		},
+	}
+
+	var report chromatic.DeficiencyReport
+	for _, group := range groups {
+		r := g.chroma.CheckDeficiencies(group)
+		report.Conflicts = append(report.Conflicts, r.Conflicts...)
+	}
+	return report
+}
+
+// ansiChromatic names the six chromatic colors of an ANSI row with the
+// contrast report's naming, such as normal.red.
+func ansiChromatic(prefix string, c ANSIColors) []chromatic.NamedColor {
+	return []chromatic.NamedColor{
+		{Name: prefix + ".red", Color: c.Red},
+		{Name: prefix + ".green", Color: c.Green},
+		{Name: prefix + ".yellow", Color: c.Yellow},
+		{Name: prefix + ".blue", Color: c.Blue},
+		{Name: prefix + ".magenta", Color: c.Magenta},
+		{Name: prefix + ".cyan", Color: c.Cyan},
+	}
+}
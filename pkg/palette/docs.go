@@ -0,0 +1,66 @@
+// Package palette maps processed color profiles to semantic UI roles for
+// theme generation. It consumes the characteristic flags computed by
+// pkg/processor and assigns background, foreground, interaction, and accent
+// roles consistently for every downstream component.
+//
+// Role Assignment:
+//   - Background: heaviest dark cluster (Dark mode) or light cluster (Light mode)
+//   - Foreground: heaviest cluster on the opposite end of the lightness scale,
+//     preferring neutral and muted clusters
+//   - Accents: chromatic clusters ranked by weight and saturation with a
+//     minimum hue separation
+//   - Derived roles: dim foreground, cursor, selection, and border
+//   - Terminal: 16-color ANSI palette with chromatic clusters snapped to the
+//     nearest canonical hue slot and missing slots synthesized in-family
+//
+// Roles the image cannot supply are synthesized from the dominant hue, or from
+// the configured fallback colors for grayscale images.
+//
+// Contrast:
+//
+// Foreground roles are repaired against the background to their configured
+// WCAG level: text at palette.text_contrast_level, accents and terminal
+// colors at palette.accent_contrast_level, and the border at
+// palette.border_contrast_level (non-text, 3:1, by default). Every pair is
+// recorded in SemanticPalette.Contrast. An unknown level name is an error.
+//
+// Color Vision:
+//
+// SemanticPalette.Deficiencies lists the ANSI and accent pairs that become
+// indistinguishable under simulated protanopia, deuteranopia, or tritanopia.
+// Colors are compared within each ANSI row and among the accents.
+//
+// Options:
+//   - Scheme: places the secondary and tertiary accents using a chromatic
+//     harmony of the primary (complementary, split-complementary, analogous,
+//     triadic, tetradic, or monochromatic), reusing extracted clusters near
+//     each target hue before synthesizing. DetectScheme reports the harmony
+//     the profile's chromatic clusters already exhibit, weighted by cluster
+//     weight, for use as a default
+//   - Overrides: pins roles to user-chosen colors, which are kept exactly as
+//     given and exempt from contrast repair
+//
+// Usage:
+//
+//	settings := settings.DefaultSettings()
+//	profile, err := processor.New(settings).ProcessImage(img)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	pal, err := palette.New(settings).Build(profile)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	triadic, err := palette.New(settings).BuildWithOptions(profile, palette.Options{
+//	    Scheme: palette.SchemeTriadic,
+//	})
+//
+//	bg := pal.Background
+//	accents := pal.Accents() // Primary, Secondary, Tertiary
+//	ansi := pal.Terminal.Colors() // ANSI 0-15
+//
+// The package follows the settings-as-methods pattern, requiring all
+// operations to be performed through a configured Generator.
+package palette
@@ -0,0 +1,41 @@
+package palette
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+)
+
+// mix linearly blends c1 toward c2 by t in sRGB space.
+// A t of 0 returns c1, a t of 1 returns c2. The result is fully opaque.
+func mix(c1, c2 color.RGBA, t float64) color.RGBA {
+	t = math.Max(0, math.Min(1, t))
+	blend := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a)*(1-t) + float64(b)*t))
+	}
+
+	return color.RGBA{
+		R: blend(c1.R, c2.R),
+		G: blend(c1.G, c2.G),
+		B: blend(c1.B, c2.B),
+		A: 255,
+	}
+}
+
+// hueDistance calculates the shortest angular distance between two hues in degrees.
+func hueDistance(h1, h2 float64) float64 {
+	diff := math.Abs(h1 - h2)
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff
+}
+
+// sortByScore orders clusters by descending score, preserving weight order on ties.
+func sortByScore(clusters []processor.ColorCluster, score func(processor.ColorCluster) float64) {
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return score(clusters[i]) > score(clusters[j])
+	})
+}
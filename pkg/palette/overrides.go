@@ -0,0 +1,48 @@
+package palette
+
+import "image/color"
+
+// Overrides pins semantic roles to fixed colors. Nil fields are assigned from
+// the profile as usual. Pinned colors are used exactly as given: contrast
+// enforcement does not adjust them, though roles derived from them (dim
+// foreground, cursor, selection, border, and terminal black and white) follow
+// the pinned values.
+type Overrides struct {
+	Background *color.RGBA
+	Foreground *color.RGBA
+	Primary    *color.RGBA
+	Secondary  *color.RGBA
+	Tertiary   *color.RGBA
+}
+
+// IsEmpty reports whether no role is pinned.
+func (o Overrides) IsEmpty() bool {
+	return o.Background == nil && o.Foreground == nil &&
+		o.Primary == nil && o.Secondary == nil && o.Tertiary == nil
+}
+
+// pinned returns the contrast target names of the pinned foreground roles.
+func (o Overrides) pinned() map[string]bool {
+	pinned := make(map[string]bool)
+	if o.Foreground != nil {
+		pinned["foreground"] = true
+	}
+	if o.Primary != nil {
+		pinned["primary"] = true
+	}
+	if o.Secondary != nil {
+		pinned["secondary"] = true
+	}
+	if o.Tertiary != nil {
+		pinned["tertiary"] = true
+	}
+	return pinned
+}
+
+// apply replaces c with the override when one is set.
+func apply(c color.RGBA, override *color.RGBA) color.RGBA {
+	if override != nil {
+		return *override
+	}
+	return c
+}
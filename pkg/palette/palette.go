@@ -0,0 +1,267 @@
+package palette
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// accentCount is the number of accent roles in a SemanticPalette.
+const accentCount = 3
+
+type Generator struct {
+	settings *settings.Settings
+	chroma   *chromatic.Chroma
+}
+
+func New(s *settings.Settings) *Generator {
+	return &Generator{
+		settings: s,
+		chroma:   chromatic.NewChroma(s),
+	}
+}
+
+// Options controls how Build assigns semantic roles.
+type Options struct {
+	Scheme    Scheme    // Accent hue relationship; empty is SchemeExtracted
+	Overrides Overrides // Roles pinned to fixed colors
+}
+
+// Build assigns semantic roles from the clusters of a ColorProfile.
+// Background and foreground are chosen by lightness relative to the profile
+// mode, accents by weight and saturation with a minimum hue separation.
+// Roles the profile cannot supply are synthesized from the chosen colors,
+// and foreground roles failing their configured WCAG level are adjusted.
+func (g *Generator) Build(profile *processor.ColorProfile) (*SemanticPalette, error) {
+	return g.BuildWithOptions(profile, Options{})
+}
+
+// BuildWithOptions assigns semantic roles like Build, arranging secondary and
+// tertiary accents by the requested scheme and replacing any role pinned by
+// the overrides. Roles selected after a pinned role account for it: the
+// foreground is chosen against a pinned background, scheme accents are placed
+// relative to a pinned primary, and derived roles use the pinned colors.
+func (g *Generator) BuildWithOptions(profile *processor.ColorProfile, opts Options) (*SemanticPalette, error) {
+	if profile == nil || len(profile.Colors) == 0 {
+		return nil, errors.ErrEmptyProfile
+	}
+
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = SchemeExtracted
+	}
+	overrides := opts.Overrides
+
+	bgIndex, bg, err := g.selectBackground(profile)
+	if err != nil {
+		return nil, err
+	}
+	bg = apply(bg, overrides.Background)
+
+	fgIndex, fg, err := g.selectForeground(profile, bg)
+	if err != nil {
+		return nil, err
+	}
+	fg = apply(fg, overrides.Foreground)
+
+	accents, candidates := g.selectAccents(profile, bg, fg, bgIndex, fgIndex)
+	accents[0] = apply(accents[0], overrides.Primary)
+	accents = g.applyScheme(scheme, accents, candidates)
+	accents[1] = apply(accents[1], overrides.Secondary)
+	accents[2] = apply(accents[2], overrides.Tertiary)
+
+	pal := &SemanticPalette{
+		Mode:          profile.Mode,
+		Scheme:        scheme,
+		Background:    bg,
+		Foreground:    fg,
+		DimForeground: mix(fg, bg, g.settings.Palette.DimForegroundMix),
+		Cursor:        accents[0],
+		Selection:     mix(accents[0], bg, g.settings.Palette.SelectionMix),
+		Border:        accents[0],
+		Primary:       accents[0],
+		Secondary:     accents[1],
+		Tertiary:      accents[2],
+		Terminal:      g.buildANSI(profile, bg, fg),
+	}
+
+	if err := g.enforceContrast(pal, overrides.pinned()); err != nil {
+		return nil, err
+	}
+	pal.Deficiencies = g.checkDeficiencies(pal)
+
+	return pal, nil
+}
+
+// selectBackground returns the heaviest cluster matching the profile mode
+// (dark clusters for Dark, light clusters for Light) with saturation capped
+// for use as a surface. When no cluster qualifies, a background is synthesized
+// from the dominant hue, or from the configured fallback for grayscale images.
+// The returned index is -1 when the background was synthesized.
+func (g *Generator) selectBackground(profile *processor.ColorProfile) (int, color.RGBA, error) {
+	dark := profile.Mode == processor.Dark
+
+	for i, cluster := range profile.Colors {
+		if (dark && cluster.IsDark) || (!dark && cluster.IsLight) {
+			return i, g.capSaturation(cluster.RGBA), nil
+		}
+	}
+
+	lightness := g.settings.Palette.LightBackgroundLightness
+	fallback := g.settings.DefaultLight
+	if dark {
+		lightness = g.settings.Palette.DarkBackgroundLightness
+		fallback = g.settings.DefaultDark
+	}
+
+	if !profile.HasColor {
+		c, err := parseFallback("background", fallback)
+		return -1, c, err
+	}
+
+	dominant := profile.Colors[0]
+	return -1, g.synthesize(dominant.Hue, dominant.Saturation, lightness), nil
+}
+
+// selectForeground returns the heaviest cluster on the opposite side of the
+// lightness scale from the background, preferring neutral and muted clusters
+// for legibility. When no cluster qualifies, a foreground is synthesized from
+// the background hue, or from the configured fallback for grayscale images.
+// The returned index is -1 when the foreground was synthesized.
+func (g *Generator) selectForeground(profile *processor.ColorProfile, bg color.RGBA) (int, color.RGBA, error) {
+	dark := profile.Mode == processor.Dark
+
+	candidate := -1
+	for i, cluster := range profile.Colors {
+		if (dark && !cluster.IsLight) || (!dark && !cluster.IsDark) {
+			continue
+		}
+		if cluster.IsNeutral || cluster.IsMuted {
+			candidate = i
+			break
+		}
+		if candidate < 0 {
+			candidate = i
+		}
+	}
+
+	if candidate >= 0 {
+		return candidate, g.capSaturation(profile.Colors[candidate].RGBA), nil
+	}
+
+	lightness := g.settings.Palette.LightForegroundLightness
+	fallback := g.settings.DefaultDark
+	if dark {
+		lightness = g.settings.Palette.DarkForegroundLightness
+		fallback = g.settings.DefaultLight
+	}
+
+	if !profile.HasColor {
+		c, err := parseFallback("foreground", fallback)
+		return -1, c, err
+	}
+
+	hsla := formats.RGBAToHSLA(bg)
+	return -1, g.synthesize(hsla.H, hsla.S, lightness), nil
+}
+
+// selectAccents ranks chromatic clusters by weight scaled by saturation and
+// picks up to three whose hues are separated by at least the configured
+// accent hue separation. Missing accents are filled from the primary's
+// triadic harmony; grayscale profiles receive a neutral ramp between
+// foreground and background instead. The ranked chromatic candidates are
+// returned for scheme placement.
+func (g *Generator) selectAccents(profile *processor.ColorProfile, bg, fg color.RGBA, bgIndex, fgIndex int) ([]color.RGBA, []processor.ColorCluster) {
+	var ranked []processor.ColorCluster
+	for i, cluster := range profile.Colors {
+		if i == bgIndex || i == fgIndex || cluster.IsNeutral {
+			continue
+		}
+		ranked = append(ranked, cluster)
+	}
+
+	sortByScore(ranked, accentScore)
+
+	var picked []processor.ColorCluster
+	for _, cluster := range ranked {
+		if len(picked) == accentCount {
+			break
+		}
+		if g.separated(cluster, picked) {
+			picked = append(picked, cluster)
+		}
+	}
+
+	accents := make([]color.RGBA, 0, accentCount)
+	for _, cluster := range picked {
+		accents = append(accents, cluster.RGBA)
+	}
+
+	if len(accents) == 0 {
+		return []color.RGBA{
+			mix(fg, bg, 0.2),
+			mix(fg, bg, 0.4),
+			mix(fg, bg, 0.55),
+		}, nil
+	}
+
+	triad := g.chroma.Triadic(formats.RGBAToHSLA(accents[0]))
+	for step := 1; len(accents) < accentCount; step++ {
+		accents = append(accents, formats.HSLAToRGBA(triad[step]))
+	}
+
+	return accents, ranked
+}
+
+// separated reports whether the cluster hue is at least the configured
+// accent hue separation away from every picked cluster.
+func (g *Generator) separated(cluster processor.ColorCluster, picked []processor.ColorCluster) bool {
+	for _, p := range picked {
+		if hueDistance(cluster.Hue, p.Hue) < g.settings.Palette.AccentHueSeparation {
+			return false
+		}
+	}
+	return true
+}
+
+// capSaturation limits the saturation of a surface or text color to the
+// configured maximum so vivid image colors do not dominate the UI.
+func (g *Generator) capSaturation(c color.RGBA) color.RGBA {
+	hsla := formats.RGBAToHSLA(c)
+	if hsla.S <= g.settings.Palette.BackgroundSaturationMax {
+		return c
+	}
+	hsla.S = g.settings.Palette.BackgroundSaturationMax
+	return formats.HSLAToRGBA(hsla)
+}
+
+// synthesize creates an opaque color at the given hue and lightness with
+// saturation capped for surface and text roles.
+func (g *Generator) synthesize(hue, saturation, lightness float64) color.RGBA {
+	s := math.Min(saturation, g.settings.Palette.BackgroundSaturationMax)
+	return formats.HSLAToRGBA(formats.NewHSL(hue, s, lightness))
+}
+
+// accentScore favors heavy clusters while boosting saturated ones.
+func accentScore(c processor.ColorCluster) float64 {
+	return c.Weight * (0.5 + c.Saturation)
+}
+
+// parseFallback parses a configured fallback hex color for the given role.
+func parseFallback(role, hex string) (color.RGBA, error) {
+	c, err := formats.ParseHex(hex)
+	if err != nil {
+		return color.RGBA{}, &errors.PaletteError{
+			Role:    role,
+			Details: fmt.Sprintf("parse fallback %q: %v", hex, err),
+			Err:     errors.ErrInvalidFallback,
+		}
+	}
+	return c, nil
+}
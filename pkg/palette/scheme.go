@@ -0,0 +1,148 @@
+package palette
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+)
+
+// Scheme selects how accent hues relate to the primary accent.
+type Scheme string
+
+const (
+	// SchemeExtracted keeps the most prominent, well-separated extracted accents.
+	SchemeExtracted Scheme = "extracted"
+
+	// SchemeComplementary pairs the primary with its complement and a close neighbor.
+	SchemeComplementary Scheme = "complementary"
+
+	// SchemeSplitComplementary places accents either side of the primary's complement.
+	SchemeSplitComplementary Scheme = "split-complementary"
+
+	// SchemeAnalogous places accents to either side of the primary.
+	SchemeAnalogous Scheme = "analogous"
+
+	// SchemeTriadic spaces accents 120° apart.
+	SchemeTriadic Scheme = "triadic"
+
+	// SchemeTetradic places accents 90° and 180° from the primary.
+	SchemeTetradic Scheme = "tetradic"
+
+	// SchemeMonochromatic keeps every accent on the primary hue, varying lightness.
+	SchemeMonochromatic Scheme = "monochromatic"
+)
+
+// Schemes lists every supported scheme.
+var Schemes = []Scheme{
+	SchemeExtracted,
+	SchemeComplementary,
+	SchemeSplitComplementary,
+	SchemeAnalogous,
+	SchemeTriadic,
+	SchemeTetradic,
+	SchemeMonochromatic,
+}
+
+// ParseScheme parses a case-insensitive scheme name. An empty name is
+// SchemeExtracted.
+func ParseScheme(name string) (Scheme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return SchemeExtracted, nil
+	}
+	for _, s := range Schemes {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid scheme %q: expected one of %s", name, SchemeNames())
+}
+
+// applyScheme replaces the secondary and tertiary accents with colors from
+// the scheme's harmony around the primary. Harmonies with fewer colors than
+// accent roles are completed with the primary's analogous neighbors, and
+// tetradic harmonies keep their first three colors. For each target the
+// highest ranked unused candidate within the configured scheme hue tolerance
+// is preferred over the generated color. Grayscale profiles without
+// candidates keep their neutral accents.
+func (g *Generator) applyScheme(scheme Scheme, accents []color.RGBA, candidates []processor.ColorCluster) []color.RGBA {
+	if scheme == SchemeExtracted || len(candidates) == 0 {
+		return accents
+	}
+
+	primary := formats.RGBAToHSLA(accents[0])
+	targets, err := g.chroma.Harmony(chromatic.Harmony(scheme), primary)
+	if err != nil {
+		return accents
+	}
+	if len(targets) < accentCount {
+		targets = append(targets, g.chroma.Analogous(primary)[1:]...)
+	}
+
+	used := map[color.RGBA]bool{accents[0]: true}
+
+	result := []color.RGBA{accents[0]}
+	for _, target := range targets[1:accentCount] {
+		if c, ok := g.nearestCandidate(candidates, target.H, used); ok {
+			used[c] = true
+			result = append(result, c)
+			continue
+		}
+		result = append(result, formats.HSLAToRGBA(target))
+	}
+
+	return result
+}
+
+// nearestCandidate returns the highest ranked unused candidate whose hue is
+// within the scheme hue tolerance of target.
+func (g *Generator) nearestCandidate(candidates []processor.ColorCluster, target float64, used map[color.RGBA]bool) (color.RGBA, bool) {
+	for _, c := range candidates {
+		if used[c.RGBA] {
+			continue
+		}
+		if hueDistance(c.Hue, target) <= g.settings.Palette.SchemeHueTolerance {
+			return c.RGBA, true
+		}
+	}
+	return color.RGBA{}, false
+}
+
+// SchemeNames returns the supported scheme names joined for messages and
+// help text.
+func SchemeNames() string {
+	names := make([]string, len(Schemes))
+	for i, s := range Schemes {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// DetectScheme classifies the harmony already present among the profile's
+// chromatic clusters, weighting each cluster hue by its weight. It returns
+// the matching scheme and the harmony confidence. Profiles without chromatic
+// clusters, or whose best harmony falls below the configured minimum scheme
+// confidence, report SchemeExtracted.
+func (g *Generator) DetectScheme(profile *processor.ColorProfile) (Scheme, float64) {
+	if profile == nil {
+		return SchemeExtracted, 0
+	}
+
+	var hues []chromatic.WeightedHue
+	for _, c := range profile.Colors {
+		if c.IsNeutral {
+			continue
+		}
+		hues = append(hues, chromatic.WeightedHue{Hue: c.Hue, Weight: c.Weight})
+	}
+
+	match := g.chroma.DetectHarmony(hues)
+	if match.Harmony == "" || match.Confidence < g.settings.Palette.SchemeMinConfidence {
+		return SchemeExtracted, match.Confidence
+	}
+	return Scheme(match.Harmony), match.Confidence
+}
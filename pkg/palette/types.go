@@ -0,0 +1,44 @@
+package palette
+
+import (
+	"image/color"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+)
+
+// SemanticPalette maps extracted colors to the UI roles consumed by theme components.
+type SemanticPalette struct {
+	Mode   processor.ThemeMode // Light or Dark theme base
+	Scheme Scheme              // Accent hue relationship used to place accents
+
+	// Base roles
+	Background    color.RGBA // Primary surface color
+	Foreground    color.RGBA // Primary text color
+	DimForeground color.RGBA // Secondary text color, blended toward background
+
+	// Interaction roles
+	Cursor    color.RGBA // Terminal and editor cursor
+	Selection color.RGBA // Selected text background
+	Border    color.RGBA // Active window and widget borders
+
+	// Accent roles, ordered by prominence
+	Primary   color.RGBA
+	Secondary color.RGBA
+	Tertiary  color.RGBA
+
+	// Terminal is the 16-color ANSI palette for terminal applications
+	Terminal ANSIPalette
+
+	// Contrast reports the foreground adjustments made to satisfy WCAG levels
+	Contrast chromatic.ContrastReport
+
+	// Deficiencies reports accent and ANSI pairs that become indistinguishable
+	// under simulated color vision deficiencies
+	Deficiencies chromatic.DeficiencyReport
+}
+
+// Accents returns the accent roles ordered by prominence.
+func (p *SemanticPalette) Accents() []color.RGBA {
+	return []color.RGBA{p.Primary, p.Secondary, p.Tertiary}
+}
@@ -11,7 +11,36 @@
 //   - Frequency-weighted color extraction sorted by visual importance
 //   - Theme mode detection based on weighted luminance analysis
 //   - Configurable clustering thresholds and UI color limits
+//   - Cluster statistics: weighted LAB centroid, member count, and variance,
+//     with each cluster represented by the member nearest its centroid
+//   - Pluggable extraction via the Extractor interface: quantized frequency
+//     histogram (default), median-cut, or octree, selected by
+//     processor.extractor
+//   - Selectable clustering: greedy single-pass merge (default) or weighted
+//     k-means in LAB or OKLab with k-means++ seeding, which reports cluster
+//     centroids rather than the most frequent color of each group
 //   - Performance optimization: <2s for 4K images, <100MB memory
+//   - Bounded sampling cost: images larger than processor.max_samples are
+//     divided into square cells with one jittered pixel sampled from each,
+//     seeded by processor.seed so the result is reproducible
+//   - Allocation-free pixel access for *image.RGBA, *image.NRGBA, and
+//     *image.YCbCr, read directly from their Pix slices
+//   - Alpha-aware extraction: pixels are counted by their straight color,
+//     nearly transparent pixels below processor.min_alpha are skipped, and
+//     translucent pixels are optionally composited over processor.alpha_matte
+//   - Spatial weighting selected by processor.spatial_weighting: uniform
+//     (default), center-weighted Gaussian, edge-weighted for bars at screen
+//     borders, or contrast-based saliency that favors the subject over a
+//     backdrop that dominates by area
+//   - Region-of-interest extraction: ProcessRegion profiles a sub-rectangle,
+//     and ProcessRegions profiles the named screen regions (top and bottom
+//     bars, center, left and right halves) from the part of the wallpaper
+//     displayed under them for a given screen resolution and fit mode
+//   - Deterministic profiles: ties are broken by color, and concurrent workers
+//     share one sampling grid, so output does not vary with map iteration or
+//     GOMAXPROCS; ColorProfile.Fingerprint identifies a profile's content
+//   - Context-aware extraction that stops on cancellation and reports
+//     per-row progress
 //
 // Usage:
 //
@@ -23,6 +52,22 @@
 //	    return err
 //	}
 //
+//	// Cancellable extraction with progress reporting
+//	profile, err = processor.ProcessImageContext(ctx, img, processor.ProcessOptions{
+//	    Progress: func(processed, total int) {
+//	        // Sampled rows completed out of total
+//	    },
+//	})
+//	if errors.Is(err, context.Canceled) {
+//	    // Extraction was aborted
+//	}
+//
+//	// Profiles of what sits under the bars once the wallpaper fills the screen
+//	regions, err := processor.ProcessRegions(ctx, img, processor.Screen{
+//	    Width: 2560, Height: 1440, Fit: processor.Fill,
+//	})
+//	topBar := regions[processor.TopRegion]
+//
 //	// Access color clusters sorted by weight (highest first)
 //	colors := profile.Colors  // []ColorCluster
 //	dominant := colors[0]     // Most prominent color
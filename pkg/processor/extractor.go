@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"image/color"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// Extractor turns sampled pixels into the weighted colors passed to
+// clustering. Sampling calls Bucket for every pixel and counts the results in
+// a histogram; Reduce then condenses the histogram into representative colors
+// with their sample counts. Implementations must be safe for concurrent calls
+// to Bucket.
+type Extractor interface {
+	// Bucket maps a sampled pixel to its histogram bucket.
+	Bucket(c color.RGBA) color.RGBA
+	// Reduce condenses a bucket histogram into representative colors.
+	// The total count is preserved.
+	Reduce(histogram map[color.RGBA]uint64) map[color.RGBA]uint64
+}
+
+// ExtractionMethod selects the Extractor used by a Processor.
+type ExtractionMethod string
+
+const (
+	// Histogram counts colors quantized to processor quantization bits.
+	// Suited to flat illustrations with few exact colors.
+	Histogram ExtractionMethod = choices.Histogram
+	// MedianCut recursively splits color space boxes at the weighted median
+	// of their widest channel. Suited to photographs with smooth gradients.
+	MedianCut ExtractionMethod = choices.MedianCut
+	// Octree merges the sparsest branches of a color octree until the
+	// target color count remains.
+	Octree ExtractionMethod = choices.Octree
+)
+
+// quantizerBits is the bucket precision for median-cut and octree, which
+// refine colors themselves and only need buckets to bound histogram size.
+const quantizerBits = 5
+
+// newExtractor returns the extractor selected by processor.extractor.
+// Settings.Validate rejects unknown methods when settings load; settings built
+// in code that skip validation fall back to Histogram.
+func newExtractor(s *settings.Settings) Extractor {
+	switch ExtractionMethod(s.Processor.Extractor) {
+	case MedianCut:
+		return NewMedianCutExtractor(s.Processor.ExtractorColors)
+	case Octree:
+		return NewOctreeExtractor(s.Processor.ExtractorColors)
+	default:
+		return NewHistogramExtractor(uint8(s.Formats.QuantizationBits))
+	}
+}
+
+// HistogramExtractor counts colors quantized to a fixed number of bits per
+// channel. Every bucket is passed to clustering unchanged.
+type HistogramExtractor struct {
+	bits uint8
+}
+
+func NewHistogramExtractor(bits uint8) *HistogramExtractor {
+	return &HistogramExtractor{bits: bits}
+}
+
+// Bucket quantizes c to the extractor's bits per channel.
+func (e *HistogramExtractor) Bucket(c color.RGBA) color.RGBA {
+	return formats.QuantizeColor(c, e.bits)
+}
+
+// Reduce returns the histogram unchanged.
+func (e *HistogramExtractor) Reduce(histogram map[color.RGBA]uint64) map[color.RGBA]uint64 {
+	return histogram
+}
+
+// histogramEntry is a histogram bucket with its count, used by quantizers
+// that operate on a list of buckets.
+type histogramEntry struct {
+	color color.RGBA
+	count uint64
+}
+
+// colorAccumulator sums count-weighted channels to produce an average color.
+type colorAccumulator struct {
+	r, g, b uint64
+	count   uint64
+}
+
+func (a *colorAccumulator) add(c color.RGBA, count uint64) {
+	a.r += uint64(c.R) * count
+	a.g += uint64(c.G) * count
+	a.b += uint64(c.B) * count
+	a.count += count
+}
+
+func (a *colorAccumulator) merge(other colorAccumulator) {
+	a.r += other.r
+	a.g += other.g
+	a.b += other.b
+	a.count += other.count
+}
+
+// average returns the rounded count-weighted mean color.
+func (a *colorAccumulator) average() color.RGBA {
+	if a.count == 0 {
+		return color.RGBA{A: 255}
+	}
+	half := a.count / 2
+	return color.RGBA{
+		R: uint8((a.r + half) / a.count),
+		G: uint8((a.g + half) / a.count),
+		B: uint8((a.b + half) / a.count),
+		A: 255,
+	}
+}
@@ -0,0 +1,29 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fingerprint returns a SHA-256 digest of the profile's content as a hex
+// string. Extraction is deterministic, so the same image processed with the
+// same settings yields the same fingerprint on any machine and with any
+// number of workers. Weights and variances are rounded to six decimal places
+// so that floating point differences between platforms cannot change the
+// digest. Derived HSL values are omitted because they follow from the
+// cluster colors.
+func (p *ColorProfile) Fingerprint() string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "mode=%s color=%t unique=%d samples=%d\n",
+		p.Mode, p.HasColor, p.UniqueColors, p.SampleCount)
+
+	for _, c := range p.Colors {
+		fmt.Fprintf(h, "%08x %08x %d %.6f %.6f %t %t %t %t %t\n",
+			packRGBA(c.RGBA), packRGBA(c.Centroid), c.Members, c.Weight, c.Variance,
+			c.IsNeutral, c.IsDark, c.IsLight, c.IsMuted, c.IsVibrant)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,235 @@
+package processor
+
+import (
+	"context"
+	"image/color"
+	"math"
+	"math/rand/v2"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// ClusteringAlgorithm selects how weighted colors are grouped into clusters.
+type ClusteringAlgorithm string
+
+const (
+	// Greedy merges colors into the heaviest similar color in a single pass.
+	// Cluster colors are the member nearest each group's weighted centroid.
+	Greedy ClusteringAlgorithm = choices.Greedy
+	// KMeans refines k-means++ seeded centroids in a perceptual color space,
+	// weighted by frequency. Cluster colors are the weighted centroids.
+	KMeans ClusteringAlgorithm = choices.KMeans
+)
+
+// point is a color in the k-means color space.
+type point [3]float64
+
+// clusterKMeans groups colors with weighted k-means in LAB or OKLab as
+// configured by processor.kmeans_color_space. Centers are seeded with
+// k-means++ using the configured seed, so results are reproducible, then
+// refined until assignments stop changing or the iteration limit is reached.
+// At least one assignment pass always runs, whatever the configured limit,
+// and ctx is checked before each pass.
+// Each cluster is represented by its center in the k-means color space, while
+// Centroid and Variance are measured in LAB as for greedy clusters. Empty
+// clusters are dropped, and clusters below the minimum cluster weight are
+// discarded.
+func (p *Processor) clusterKMeans(ctx context.Context, colors []WeightedColor) ([]ColorCluster, error) {
+	if len(colors) == 0 {
+		return nil, nil
+	}
+
+	// Order the input so seeding does not depend on map iteration order
+	sortWeightedColors(colors)
+
+	oklab := p.settings.Processor.KMeansColorSpace != choices.LAB
+	points := make([]point, len(colors))
+	for i, c := range colors {
+		points[i] = toPoint(c.RGBA, oklab)
+	}
+
+	k := p.settings.Processor.KMeansClusters
+	if k <= 0 || k > len(colors) {
+		k = len(colors)
+	}
+
+	seed := uint64(p.settings.Processor.Seed)
+	rng := rand.New(rand.NewPCG(seed, seed))
+	centers := seedCenters(points, colors, k, rng)
+
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = -1
+	}
+
+	iterations := max(1, p.settings.Processor.KMeansMaxIterations)
+	for iter := 0; iter < iterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for i, pt := range points {
+			nearest := nearestCenter(pt, centers)
+			if nearest != assignments[i] {
+				assignments[i] = nearest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		centers = updateCenters(points, colors, assignments, centers)
+	}
+
+	members := make([][]WeightedColor, len(centers))
+	for i, c := range colors {
+		members[assignments[i]] = append(members[assignments[i]], c)
+	}
+
+	clusters := make([]ColorCluster, 0, len(centers))
+	for i, center := range centers {
+		if len(members[i]) == 0 {
+			continue
+		}
+		stats := newClusterStats(members[i])
+		if stats.weight < p.settings.Processor.MinClusterWeight {
+			continue
+		}
+		cluster := p.NewCluster(fromPoint(center, oklab), stats.weight)
+		stats.apply(&cluster)
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
+
+// seedCenters chooses k initial centers with weighted k-means++: the first
+// center is drawn in proportion to color weight, and each later center in
+// proportion to weight times squared distance from the nearest chosen center.
+func seedCenters(points []point, colors []WeightedColor, k int, rng *rand.Rand) []point {
+	centers := make([]point, 0, k)
+	centers = append(centers, points[weightedIndex(colors, nil, rng)])
+
+	distances := make([]float64, len(points))
+	for i, pt := range points {
+		distances[i] = squaredDistance(pt, centers[0])
+	}
+
+	for len(centers) < k {
+		idx := weightedIndex(colors, distances, rng)
+		if idx < 0 {
+			// Every remaining point coincides with a center
+			break
+		}
+		centers = append(centers, points[idx])
+
+		for i, pt := range points {
+			distances[i] = math.Min(distances[i], squaredDistance(pt, points[idx]))
+		}
+	}
+
+	return centers
+}
+
+// weightedIndex draws an index in proportion to color weight, scaled by
+// distances when given. Returns -1 when every scaled weight is zero.
+func weightedIndex(colors []WeightedColor, distances []float64, rng *rand.Rand) int {
+	scaled := func(i int) float64 {
+		if distances == nil {
+			return colors[i].Weight
+		}
+		return colors[i].Weight * distances[i]
+	}
+
+	var total float64
+	for i := range colors {
+		total += scaled(i)
+	}
+	if total == 0 {
+		return -1
+	}
+
+	target := rng.Float64() * total
+	last := -1
+	for i := range colors {
+		w := scaled(i)
+		if w == 0 {
+			continue
+		}
+		last = i
+		target -= w
+		if target < 0 {
+			return i
+		}
+	}
+	return last
+}
+
+// updateCenters moves each center to the weighted mean of its assigned
+// points. Centers with no points keep their position.
+func updateCenters(points []point, colors []WeightedColor, assignments []int, centers []point) []point {
+	sums := make([]point, len(centers))
+	weights := make([]float64, len(centers))
+
+	for i, pt := range points {
+		c := assignments[i]
+		w := colors[i].Weight
+		for d := range pt {
+			sums[c][d] += pt[d] * w
+		}
+		weights[c] += w
+	}
+
+	updated := make([]point, len(centers))
+	for c := range centers {
+		if weights[c] == 0 {
+			updated[c] = centers[c]
+			continue
+		}
+		for d := range sums[c] {
+			updated[c][d] = sums[c][d] / weights[c]
+		}
+	}
+	return updated
+}
+
+// nearestCenter returns the index of the center closest to pt.
+func nearestCenter(pt point, centers []point) int {
+	nearest := 0
+	best := math.Inf(1)
+	for i, c := range centers {
+		if d := squaredDistance(pt, c); d < best {
+			best = d
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+func squaredDistance(a, b point) float64 {
+	d0, d1, d2 := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return d0*d0 + d1*d1 + d2*d2
+}
+
+func toPoint(c color.RGBA, oklab bool) point {
+	if oklab {
+		lab := formats.RGBAToOKLab(c)
+		return point{lab.L, lab.A, lab.B}
+	}
+	lab := formats.RGBAToLAB(c)
+	return point{lab.L, lab.A, lab.B}
+}
+
+func fromPoint(pt point, oklab bool) color.RGBA {
+	if oklab {
+		return formats.OKLabToRGBA(formats.NewOKLab(pt[0], pt[1], pt[2]))
+	}
+	return formats.LABToRGBA(formats.LAB{L: pt[0], A: pt[1], B: pt[2]})
+}
+
+// packRGBA packs a color into a single integer for ordering.
+func packRGBA(c color.RGBA) uint32 {
+	return uint32(c.R)<<24 | uint32(c.G)<<16 | uint32(c.B)<<8 | uint32(c.A)
+}
@@ -0,0 +1,141 @@
+package processor
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// MedianCutExtractor reduces a histogram with the median-cut algorithm. The
+// box with the largest count-weighted extent is split at the weighted median
+// of its widest channel until the target number of boxes is reached or no box
+// can be split. Each box contributes its count-weighted average color.
+type MedianCutExtractor struct {
+	colors int
+}
+
+func NewMedianCutExtractor(colors int) *MedianCutExtractor {
+	return &MedianCutExtractor{colors: colors}
+}
+
+// Bucket quantizes c to 5 bits per channel.
+func (e *MedianCutExtractor) Bucket(c color.RGBA) color.RGBA {
+	return formats.QuantizeColor(c, quantizerBits)
+}
+
+// Reduce splits the histogram into at most the target number of boxes.
+func (e *MedianCutExtractor) Reduce(histogram map[color.RGBA]uint64) map[color.RGBA]uint64 {
+	if len(histogram) == 0 {
+		return histogram
+	}
+
+	boxes := []*medianBox{newMedianBox(sortedEntries(histogram))}
+
+	for len(boxes) < e.colors {
+		idx := -1
+		var best uint64
+		for i, box := range boxes {
+			if score := box.score(); len(box.entries) > 1 && score > best {
+				idx, best = i, score
+			}
+		}
+		if idx < 0 {
+			break
+		}
+
+		low, high := boxes[idx].split()
+		boxes[idx] = low
+		boxes = append(boxes, high)
+	}
+
+	reduced := make(map[color.RGBA]uint64, len(boxes))
+	for _, box := range boxes {
+		var acc colorAccumulator
+		for _, entry := range box.entries {
+			acc.add(entry.color, entry.count)
+		}
+		reduced[acc.average()] += acc.count
+	}
+	return reduced
+}
+
+// medianBox is a set of histogram entries bounded by per-channel ranges.
+type medianBox struct {
+	entries  []histogramEntry
+	count    uint64
+	min, max [3]uint8
+}
+
+func newMedianBox(entries []histogramEntry) *medianBox {
+	box := &medianBox{
+		entries: entries,
+		min:     [3]uint8{255, 255, 255},
+	}
+	for _, entry := range entries {
+		box.count += entry.count
+		for ch, v := range channels(entry.color) {
+			box.min[ch] = min(box.min[ch], v)
+			box.max[ch] = max(box.max[ch], v)
+		}
+	}
+	return box
+}
+
+// widest returns the channel with the largest range and that range.
+func (b *medianBox) widest() (int, uint8) {
+	ch := 0
+	for c := 1; c < 3; c++ {
+		if b.max[c]-b.min[c] > b.max[ch]-b.min[ch] {
+			ch = c
+		}
+	}
+	return ch, b.max[ch] - b.min[ch]
+}
+
+// score ranks boxes for splitting: boxes that are both populous and wide
+// are split first.
+func (b *medianBox) score() uint64 {
+	_, extent := b.widest()
+	return b.count * uint64(extent)
+}
+
+// split divides the box at the count-weighted median of its widest channel.
+// Both halves keep at least one entry.
+func (b *medianBox) split() (*medianBox, *medianBox) {
+	ch, _ := b.widest()
+	sort.SliceStable(b.entries, func(i, j int) bool {
+		return channels(b.entries[i].color)[ch] < channels(b.entries[j].color)[ch]
+	})
+
+	half := b.count / 2
+	var running uint64
+	cut := 1
+	for i, entry := range b.entries[:len(b.entries)-1] {
+		running += entry.count
+		cut = i + 1
+		if running >= half {
+			break
+		}
+	}
+
+	return newMedianBox(b.entries[:cut]), newMedianBox(b.entries[cut:])
+}
+
+// channels returns the red, green, and blue channels of c.
+func channels(c color.RGBA) [3]uint8 {
+	return [3]uint8{c.R, c.G, c.B}
+}
+
+// sortedEntries returns the histogram as a list ordered by color so that
+// quantizers do not depend on map iteration order.
+func sortedEntries(histogram map[color.RGBA]uint64) []histogramEntry {
+	entries := make([]histogramEntry, 0, len(histogram))
+	for c, count := range histogram {
+		entries = append(entries, histogramEntry{color: c, count: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return packRGBA(entries[i].color) < packRGBA(entries[j].color)
+	})
+	return entries
+}
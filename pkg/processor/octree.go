@@ -0,0 +1,123 @@
+package processor
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// OctreeExtractor reduces a histogram with octree quantization. Colors are
+// inserted into a tree that branches on one bit of each channel per level,
+// then the sparsest branches at the deepest level are merged into their
+// parents until no more than the target number of leaves remain. Each leaf
+// contributes its count-weighted average color.
+type OctreeExtractor struct {
+	colors int
+}
+
+func NewOctreeExtractor(colors int) *OctreeExtractor {
+	return &OctreeExtractor{colors: colors}
+}
+
+// Bucket quantizes c to 5 bits per channel.
+func (e *OctreeExtractor) Bucket(c color.RGBA) color.RGBA {
+	return formats.QuantizeColor(c, quantizerBits)
+}
+
+// Reduce merges octree branches until at most the target number of leaves
+// remain.
+func (e *OctreeExtractor) Reduce(histogram map[color.RGBA]uint64) map[color.RGBA]uint64 {
+	if len(histogram) == 0 {
+		return histogram
+	}
+
+	tree := &octree{}
+	root := &octreeNode{}
+	for _, entry := range sortedEntries(histogram) {
+		tree.insert(root, entry, 0)
+	}
+
+	for level := quantizerBits - 1; level >= 0 && tree.leaves > e.colors; level-- {
+		nodes := tree.levels[level]
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return nodes[i].acc.count < nodes[j].acc.count
+		})
+		for _, node := range nodes {
+			if tree.leaves <= e.colors {
+				break
+			}
+			tree.leaves -= node.merge() - 1
+		}
+	}
+
+	reduced := make(map[color.RGBA]uint64, tree.leaves)
+	root.collect(reduced)
+	return reduced
+}
+
+// octree tracks the internal nodes at each level and the current leaf count.
+type octree struct {
+	levels [quantizerBits][]*octreeNode
+	leaves int
+}
+
+// octreeNode is a branch with children or, once children is nil, a leaf.
+// acc holds the totals of every color beneath the node.
+type octreeNode struct {
+	children *[8]*octreeNode
+	acc      colorAccumulator
+}
+
+// insert adds entry beneath node, creating branches down to the quantizer
+// depth. The bucket's significant bits select the child at each level.
+func (t *octree) insert(node *octreeNode, entry histogramEntry, level int) {
+	node.acc.add(entry.color, entry.count)
+	if level == quantizerBits {
+		if node.acc.count == uint64(entry.count) {
+			t.leaves++
+		}
+		return
+	}
+
+	if node.children == nil {
+		node.children = &[8]*octreeNode{}
+		t.levels[level] = append(t.levels[level], node)
+	}
+
+	shift := 7 - level
+	idx := (entry.color.R>>shift&1)<<2 | (entry.color.G>>shift&1)<<1 | entry.color.B>>shift&1
+	if node.children[idx] == nil {
+		node.children[idx] = &octreeNode{}
+	}
+	t.insert(node.children[idx], entry, level+1)
+}
+
+// merge turns the node into a leaf and returns how many leaves it replaced.
+// Children are already leaves because deeper levels are merged first.
+func (n *octreeNode) merge() int {
+	if n.children == nil {
+		return 1
+	}
+	merged := 0
+	for _, child := range n.children {
+		if child != nil {
+			merged++
+		}
+	}
+	n.children = nil
+	return merged
+}
+
+// collect adds the average color and count of every leaf beneath n.
+func (n *octreeNode) collect(reduced map[color.RGBA]uint64) {
+	if n.children == nil {
+		reduced[n.acc.average()] += n.acc.count
+		return
+	}
+	for _, child := range n.children {
+		if child != nil {
+			child.collect(reduced)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// pixelReader returns a function reading the pixel at (x, y) of img as
+// straight (non-premultiplied) color.NRGBA. The common decoder outputs,
+// *image.RGBA, *image.NRGBA (PNG) and *image.YCbCr (JPEG), are read straight
+// from their Pix slices without boxing each pixel in a color.Color. Results
+// match color.NRGBAModel.Convert(img.At(x, y)) exactly; other image types use
+// that conversion directly.
+func pixelReader(img image.Image) func(x, y int) color.NRGBA {
+	switch src := img.(type) {
+	case *image.RGBA:
+		return func(x, y int) color.NRGBA {
+			i := src.PixOffset(x, y)
+			s := src.Pix[i : i+4 : i+4]
+			return unpremultiply(color.RGBA{R: s[0], G: s[1], B: s[2], A: s[3]})
+		}
+	case *image.NRGBA:
+		return func(x, y int) color.NRGBA {
+			i := src.PixOffset(x, y)
+			s := src.Pix[i : i+4 : i+4]
+			return color.NRGBA{R: s[0], G: s[1], B: s[2], A: s[3]}
+		}
+	case *image.YCbCr:
+		return func(x, y int) color.NRGBA {
+			yi := src.YOffset(x, y)
+			ci := src.COffset(x, y)
+			// color.YCbCr.RGBA keeps 16-bit precision, which can round
+			// differently from color.YCbCrToRGB
+			r, g, b, _ := color.YCbCr{Y: src.Y[yi], Cb: src.Cb[ci], Cr: src.Cr[ci]}.RGBA()
+			return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff}
+		}
+	default:
+		return func(x, y int) color.NRGBA {
+			return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+		}
+	}
+}
+
+// unpremultiply converts a premultiplied color to straight alpha with the
+// same 16-bit arithmetic as color.NRGBAModel.
+func unpremultiply(c color.RGBA) color.NRGBA {
+	switch c.A {
+	case 0xff:
+		return color.NRGBA{R: c.R, G: c.G, B: c.B, A: 0xff}
+	case 0:
+		return color.NRGBA{}
+	}
+	r, g, b, a := c.RGBA()
+	r = (r * 0xffff) / a
+	g = (g * 0xffff) / a
+	b = (b * 0xffff) / a
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: c.A}
+}
+
+// alphaResolver turns straight-alpha pixels into the opaque colors counted
+// during extraction. Pixels below the minimum alpha are skipped; the rest
+// keep their straight color or, when a matte is configured, are composited
+// over it.
+type alphaResolver struct {
+	minAlpha  uint8
+	matte     color.RGBA
+	composite bool
+}
+
+// newAlphaResolver builds a resolver from processor.min_alpha and
+// processor.alpha_matte. Fully transparent pixels carry no color and are
+// always skipped. The matte's own alpha is ignored.
+func (p *Processor) newAlphaResolver() (alphaResolver, error) {
+	minAlpha := max(1, min(255, p.settings.Processor.MinAlpha))
+	resolver := alphaResolver{minAlpha: uint8(minAlpha)}
+
+	if hex := p.settings.Processor.AlphaMatte; hex != "" {
+		matte, err := formats.ParseHex(hex)
+		if err != nil {
+			return alphaResolver{}, &errors.ExtractionError{
+				Stage:   "alpha",
+				Details: fmt.Sprintf("parse matte %q", hex),
+				Err:     err,
+			}
+		}
+		resolver.matte = matte
+		resolver.composite = true
+	}
+
+	return resolver, nil
+}
+
+// resolve returns the opaque color to count for c, or false if c is too
+// transparent to count.
+func (r alphaResolver) resolve(c color.NRGBA) (color.RGBA, bool) {
+	if c.A < r.minAlpha {
+		return color.RGBA{}, false
+	}
+	if c.A == 0xff || !r.composite {
+		return color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff}, true
+	}
+
+	a := uint32(c.A)
+	blend := func(s, m uint8) uint8 {
+		return uint8((uint32(s)*a + uint32(m)*(0xff-a) + 0x7f) / 0xff)
+	}
+	return color.RGBA{
+		R: blend(c.R, r.matte.R),
+		G: blend(c.G, r.matte.G),
+		B: blend(c.B, r.matte.B),
+		A: 0xff,
+	}, true
+}
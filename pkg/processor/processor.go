@@ -1,9 +1,12 @@
 package processor
 
 import (
+	"context"
 	"fmt"
 	"image"
 	"image/color"
+	"math"
+	"math/rand/v2"
 	"runtime"
 	"sort"
 
@@ -13,137 +16,268 @@ import (
 )
 
 type Processor struct {
-	settings *settings.Settings
-	chroma   *chromatic.Chroma
+	settings  *settings.Settings
+	chroma    *chromatic.Chroma
+	extractor Extractor
 }
 
 func New(s *settings.Settings) *Processor {
 	return &Processor{
-		settings: s,
-		chroma:   chromatic.NewChroma(s),
+		settings:  s,
+		chroma:    chromatic.NewChroma(s),
+		extractor: newExtractor(s),
 	}
 }
 
+// ProcessImage extracts a color profile from img. It is equivalent to
+// ProcessImageContext with a background context and no options.
 func (p *Processor) ProcessImage(img image.Image) (*ColorProfile, error) {
-	colorFreq, totalSamples := p.extractColors(img)
+	return p.ProcessImageContext(context.Background(), img, ProcessOptions{})
+}
+
+// ProcessImageContext extracts a color profile from img. Sampling, the
+// saliency weighting pre-pass, and clustering stop as soon as ctx is
+// cancelled, in which case the context error is returned. Progress, when set
+// in opts, is reported once per sampled row.
+func (p *Processor) ProcessImageContext(ctx context.Context, img image.Image, opts ProcessOptions) (*ColorProfile, error) {
+	samples, err := p.extractColors(ctx, img, opts.Progress)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(colorFreq) == 0 {
+	if len(samples.colors) == 0 {
 		return nil, fmt.Errorf("no colors found in image")
 	}
 
-	weighted := p.createWeightedColors(colorFreq, totalSamples)
-	clusters := p.clusterColors(weighted)
+	colorFreq := p.extractor.Reduce(samples.colors)
+
+	weighted := p.createWeightedColors(colorFreq, samples.total)
+	clusters, err := p.clusterColors(ctx, weighted)
+	if err != nil {
+		return nil, err
+	}
 	clusters = p.filterForUI(clusters)
 
 	if len(clusters) == 0 {
 		return nil, fmt.Errorf("no suitable colors found for UI theme")
 	}
 
-	sort.Slice(clusters, func(i, j int) bool {
-		return clusters[i].Weight > clusters[j].Weight
-	})
+	sortClusters(clusters)
 
 	mode := p.calculateThemeMode(clusters)
 	hasColor := p.hasSignificantColor(clusters)
 
 	return &ColorProfile{
-		Mode:       mode,
-		Colors:     clusters,
-		HasColor:   hasColor,
-		ColorCount: len(clusters),
+		Mode:         mode,
+		Colors:       clusters,
+		HasColor:     hasColor,
+		ColorCount:   len(clusters),
+		UniqueColors: len(samples.colors),
+		SampleCount:  int(samples.count),
 	}, nil
 }
 
-func (p *Processor) extractColors(img image.Image) (map[color.RGBA]uint32, uint32) {
+// colorSamples is a histogram of sampled bucket colors. Total is the sum of
+// the histogram counts, which equals the number of pixels sampled unless
+// spatial weighting scales their contributions.
+type colorSamples struct {
+	colors map[color.RGBA]uint64
+	total  uint64
+	count  uint32
+}
+
+func (s *colorSamples) merge(other colorSamples) {
+	for c, n := range other.colors {
+		s.colors[c] += n
+	}
+	s.total += other.total
+	s.count += other.count
+}
+
+// sampler holds the per-image state shared by the extraction loops. The
+// image is divided into square cells of step pixels, and one pixel is sampled
+// from each.
+type sampler struct {
+	bounds image.Rectangle
+	step   int
+	seed   uint64
+	read   func(x, y int) color.NRGBA
+	alpha  alphaResolver
+	weight pixelWeight
+}
+
+// rows returns the number of cell rows covering the image.
+func (s *sampler) rows() int {
+	return sampledRows(s.bounds.Min.Y, s.bounds.Max.Y, s.step)
+}
+
+// sampleRow adds one pixel from each cell of the given cell row to samples.
+// The pixel is placed at a random offset within its cell, so patterns with
+// the same period as the cells are not aliased. Each row draws offsets from
+// its own stream of the configured seed, which keeps samples independent of
+// how rows are divided among workers.
+func (p *Processor) sampleRow(s *sampler, row int, samples *colorSamples) {
+	minY := s.bounds.Min.Y + row*s.step
+	cellH := min(s.step, s.bounds.Max.Y-minY)
+
+	var rng *rand.Rand
+	if s.step > 1 {
+		rng = rand.New(rand.NewPCG(s.seed, uint64(row)))
+	}
+
+	for minX := s.bounds.Min.X; minX < s.bounds.Max.X; minX += s.step {
+		x, y := minX, minY
+		if rng != nil {
+			x += rng.IntN(min(s.step, s.bounds.Max.X-minX))
+			y += rng.IntN(cellH)
+		}
+
+		rgba, ok := s.alpha.resolve(s.read(x, y))
+		if !ok {
+			continue
+		}
+		rgba = p.extractor.Bucket(rgba)
+		w := uint64(s.weight(x, y))
+		samples.colors[rgba] += w
+		samples.total += w
+		samples.count++
+	}
+}
+
+func (p *Processor) extractColors(ctx context.Context, img image.Image, progress ProgressFunc) (colorSamples, error) {
 	bounds := img.Bounds()
 	width := bounds.Dx()
 	height := bounds.Dy()
 	totalPixels := width * height
 
-	sampleRate := p.calculateSampleRate(width, height)
+	alpha, err := p.newAlphaResolver()
+	if err != nil {
+		return colorSamples{}, err
+	}
 
-	if totalPixels > 100000 && runtime.GOMAXPROCS(0) > 1 {
-		return p.extractColorsConcurrent(img, sampleRate)
+	if err := ctx.Err(); err != nil {
+		return colorSamples{}, err
 	}
 
-	return p.extractColorsSequential(img, sampleRate)
-}
+	read := pixelReader(img)
+	weight, err := p.newPixelWeight(ctx, img, read, alpha)
+	if err != nil {
+		return colorSamples{}, err
+	}
 
-func (p *Processor) extractColorsSequential(img image.Image, sampleRate int) (map[color.RGBA]uint32, uint32) {
-	bounds := img.Bounds()
-	colorFreq := make(map[color.RGBA]uint32)
-	var totalSamples uint32
-
-	for y := bounds.Min.Y; y < bounds.Max.Y; y += sampleRate {
-		for x := bounds.Min.X; x < bounds.Max.X; x += sampleRate {
-			rgba := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
-			rgba = formats.QuantizeColor(rgba, uint8(p.settings.Formats.QuantizationBits))
-			colorFreq[rgba]++
-			totalSamples++
-		}
+	s := &sampler{
+		bounds: bounds,
+		step:   p.sampleStep(width, height),
+		seed:   uint64(p.settings.Processor.Seed),
+		read:   read,
+		alpha:  alpha,
+		weight: weight,
+	}
+
+	if totalPixels > 100000 && runtime.GOMAXPROCS(0) > 1 {
+		return p.extractColorsConcurrent(ctx, s, progress)
 	}
 
-	return colorFreq, totalSamples
+	return p.extractColorsSequential(ctx, s, progress)
 }
 
-func (p *Processor) extractColorsConcurrent(img image.Image, sampleRate int) (map[color.RGBA]uint32, uint32) {
-	bounds := img.Bounds()
-	numWorkers := runtime.GOMAXPROCS(0)
-	rowsPerWorker := bounds.Dy() / numWorkers
+func (p *Processor) extractColorsSequential(ctx context.Context, s *sampler, progress ProgressFunc) (colorSamples, error) {
+	samples := colorSamples{colors: make(map[color.RGBA]uint64)}
+	totalRows := s.rows()
+	reporter := newProgressReporter(progress, totalRows)
+	done := ctx.Done()
+
+	for row := 0; row < totalRows; row++ {
+		select {
+		case <-done:
+			return colorSamples{}, ctx.Err()
+		default:
+		}
 
-	if rowsPerWorker == 0 {
-		rowsPerWorker = 1
-		numWorkers = bounds.Dy()
+		p.sampleRow(s, row, &samples)
+		reporter.advance()
 	}
 
-	type result struct {
-		colors  map[color.RGBA]uint32
-		samples uint32
-	}
+	return samples, nil
+}
+
+// extractColorsConcurrent divides the sampled rows among workers. Rows are
+// taken from the same grid as sequential extraction, so the samples do not
+// depend on the number of workers.
+func (p *Processor) extractColorsConcurrent(ctx context.Context, s *sampler, progress ProgressFunc) (colorSamples, error) {
+	totalRows := s.rows()
+	numWorkers := min(runtime.GOMAXPROCS(0), totalRows)
+	rowsPerWorker := totalRows / numWorkers
 
-	results := make(chan result, numWorkers)
+	reporter := newProgressReporter(progress, totalRows)
+	done := ctx.Done()
+	results := make(chan colorSamples, numWorkers)
 
 	for i := 0; i < numWorkers; i++ {
-		startY := bounds.Min.Y + i*rowsPerWorker
-		endY := startY + rowsPerWorker
+		startRow := i * rowsPerWorker
+		endRow := startRow + rowsPerWorker
 		if i == numWorkers-1 {
-			endY = bounds.Max.Y
+			endRow = totalRows
 		}
 
-		go func(startY, endY int) {
-			colors := make(map[color.RGBA]uint32)
-			var samples uint32
+		go func(startRow, endRow int) {
+			samples := colorSamples{colors: make(map[color.RGBA]uint64)}
 
-			for y := startY; y < endY; y += sampleRate {
-				for x := bounds.Min.X; x < bounds.Max.X; x += sampleRate {
-					rgba := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
-					rgba = formats.QuantizeColor(rgba, uint8(p.settings.Formats.QuantizationBits))
-					colors[rgba]++
-					samples++
+			for row := startRow; row < endRow; row++ {
+				select {
+				case <-done:
+					results <- colorSamples{}
+					return
+				default:
 				}
+
+				p.sampleRow(s, row, &samples)
+				reporter.advance()
 			}
 
-			results <- result{colors: colors, samples: samples}
-		}(startY, endY)
+			results <- samples
+		}(startRow, endRow)
 	}
 
-	finalColors := make(map[color.RGBA]uint32)
-	var totalSamples uint32
+	final := colorSamples{colors: make(map[color.RGBA]uint64)}
 
 	for i := 0; i < numWorkers; i++ {
-		res := <-results
-		for c, count := range res.colors {
-			finalColors[c] += count
-		}
-		totalSamples += res.samples
+		final.merge(<-results)
 	}
 
-	return finalColors, totalSamples
+	// Workers stop at the next row once ctx is done
+	if err := ctx.Err(); err != nil {
+		return colorSamples{}, err
+	}
+
+	return final, nil
 }
 
-func (p *Processor) createWeightedColors(colorFreq map[color.RGBA]uint32, totalSamples uint32) []WeightedColor {
+// sortWeightedColors orders colors by descending weight, breaking ties by
+// color so the order never depends on map iteration.
+func sortWeightedColors(colors []WeightedColor) {
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i].Weight != colors[j].Weight {
+			return colors[i].Weight > colors[j].Weight
+		}
+		return packRGBA(colors[i].RGBA) < packRGBA(colors[j].RGBA)
+	})
+}
+
+// sortClusters orders clusters by descending weight, breaking ties by color
+// and keeping the existing order of clusters that are otherwise equal.
+func sortClusters(clusters []ColorCluster) {
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Weight != clusters[j].Weight {
+			return clusters[i].Weight > clusters[j].Weight
+		}
+		return packRGBA(clusters[i].RGBA) < packRGBA(clusters[j].RGBA)
+	})
+}
+
+func (p *Processor) createWeightedColors(colorFreq map[color.RGBA]uint64, totalSamples uint64) []WeightedColor {
 	weighted := make([]WeightedColor, 0, len(colorFreq))
-	minFreq := uint32(float64(totalSamples) * p.settings.Processor.MinFrequency)
+	minFreq := uint64(float64(totalSamples) * p.settings.Processor.MinFrequency)
 
 	for c, freq := range colorFreq {
 		if freq >= minFreq {
@@ -154,14 +288,29 @@ func (p *Processor) createWeightedColors(colorFreq map[color.RGBA]uint32, totalS
 	return weighted
 }
 
-func (p *Processor) clusterColors(colors []WeightedColor) []ColorCluster {
+// clusterColors groups weighted colors with the configured clustering
+// algorithm. Settings.Validate rejects unknown algorithms when settings load;
+// settings built in code that skip validation fall back to greedy.
+// Clustering stops with the context error once ctx is cancelled.
+func (p *Processor) clusterColors(ctx context.Context, colors []WeightedColor) ([]ColorCluster, error) {
+	switch ClusteringAlgorithm(p.settings.Processor.ClusteringAlgorithm) {
+	case KMeans:
+		return p.clusterKMeans(ctx, colors)
+	default:
+		return p.clusterGreedy(ctx, colors)
+	}
+}
+
+// clusterGreedy merges colors in a single pass from heaviest to lightest. The
+// heaviest unmerged color seeds a cluster and absorbs every remaining color
+// similar to it; the finished cluster is represented by its member nearest
+// the weighted centroid.
+func (p *Processor) clusterGreedy(ctx context.Context, colors []WeightedColor) ([]ColorCluster, error) {
 	if len(colors) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	sort.Slice(colors, func(i, j int) bool {
-		return colors[i].Weight > colors[j].Weight
-	})
+	sortWeightedColors(colors)
 
 	var clusters []ColorCluster
 	used := make([]bool, len(colors))
@@ -170,8 +319,11 @@ func (p *Processor) clusterColors(colors []WeightedColor) []ColorCluster {
 		if used[i] {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-		cluster := p.createCluster(color)
+		members := []WeightedColor{color}
 		used[i] = true
 
 		for j := i + 1; j < len(colors); j++ {
@@ -180,25 +332,43 @@ func (p *Processor) clusterColors(colors []WeightedColor) []ColorCluster {
 			}
 
 			if p.chroma.ColorsSimilar(color.RGBA, colors[j].RGBA) {
-				cluster.Weight += colors[j].Weight
+				members = append(members, colors[j])
 				used[j] = true
 			}
 		}
 
+		cluster := p.createCluster(members)
+
 		if cluster.Weight >= p.settings.Processor.MinClusterWeight {
 			clusters = append(clusters, cluster)
 		}
 	}
 
-	return clusters
+	return clusters, nil
+}
+
+// createCluster builds a cluster from its members, represented by the member
+// nearest their weighted LAB centroid so the cluster color is both a real
+// image color and typical of the group.
+func (p *Processor) createCluster(members []WeightedColor) ColorCluster {
+	stats := newClusterStats(members)
+	cluster := p.NewCluster(members[stats.nearest].RGBA, stats.weight)
+	stats.apply(&cluster)
+	return cluster
 }
 
-func (p *Processor) createCluster(wc WeightedColor) ColorCluster {
-	hsla := formats.RGBAToHSLA(wc.RGBA)
+// NewCluster creates a ColorCluster for a color with the given weight,
+// classifying it with the configured chromatic thresholds. It allows profiles
+// to be reconstructed from stored cluster colors without re-reading the image.
+// The centroid is the color itself; member count and variance are left zero
+// because the members are unknown.
+func (p *Processor) NewCluster(c color.RGBA, weight float64) ColorCluster {
+	hsla := formats.RGBAToHSLA(c)
 
 	return ColorCluster{
-		RGBA:       wc.RGBA,
-		Weight:     wc.Weight,
+		RGBA:       c,
+		Centroid:   c,
+		Weight:     weight,
 		Lightness:  hsla.L,
 		Saturation: hsla.S,
 		Hue:        hsla.H,
@@ -237,9 +407,7 @@ func (p *Processor) filterForUI(clusters []ColorCluster) []ColorCluster {
 		filtered = append(filtered, cluster)
 	}
 
-	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].Weight > filtered[j].Weight
-	})
+	sortClusters(filtered)
 
 	if len(filtered) > p.settings.Processor.MaxUIColors {
 		filtered = filtered[:p.settings.Processor.MaxUIColors]
@@ -287,17 +455,24 @@ func (p *Processor) hasSignificantColor(clusters []ColorCluster) bool {
 	return colorWeight > p.settings.Processor.SignificantColorThreshold
 }
 
-func (p *Processor) calculateSampleRate(width, height int) int {
+// sampleStep returns the cell size that keeps the number of samples within
+// processor.max_samples. Images within the budget, or any image when the
+// budget is not positive, are sampled at every pixel.
+func (p *Processor) sampleStep(width, height int) int {
+	budget := p.settings.Processor.MaxSamples
 	pixels := width * height
-
-	switch {
-	case pixels > 8000000:
-		return 4
-	case pixels > 4000000:
-		return 3
-	case pixels > 2000000:
-		return 2
-	default:
+	if budget <= 0 || pixels <= budget {
 		return 1
 	}
-}
\ No newline at end of file
+
+	step := int(math.Ceil(math.Sqrt(float64(pixels) / float64(budget))))
+	for cellCount(width, step)*cellCount(height, step) > budget {
+		step++
+	}
+	return step
+}
+
+// cellCount returns how many cells of size step cover length pixels.
+func cellCount(length, step int) int {
+	return (length + step - 1) / step
+}
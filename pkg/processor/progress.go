@@ -0,0 +1,47 @@
+package processor
+
+import "sync"
+
+// ProgressFunc receives extraction progress as the number of sampled rows
+// processed and the total number of rows to sample.
+type ProgressFunc func(processed, total int)
+
+// ProcessOptions configures a single ProcessImageContext call.
+type ProcessOptions struct {
+	// Progress is called after each sampled row. Calls are serialized, so
+	// the function does not need to be safe for concurrent use, but it runs
+	// on extraction goroutines and should return quickly.
+	Progress ProgressFunc
+}
+
+// progressReporter serializes progress callbacks from extraction workers.
+type progressReporter struct {
+	mu        sync.Mutex
+	fn        ProgressFunc
+	processed int
+	total     int
+}
+
+func newProgressReporter(fn ProgressFunc, total int) *progressReporter {
+	return &progressReporter{fn: fn, total: total}
+}
+
+// advance records one processed row and reports it.
+func (r *progressReporter) advance() {
+	if r.fn == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processed++
+	r.fn(r.processed, r.total)
+}
+
+// sampledRows returns how many rows a stride of sampleRate visits in
+// [startY, endY).
+func sampledRows(startY, endY, sampleRate int) int {
+	if endY <= startY {
+		return 0
+	}
+	return (endY - startY + sampleRate - 1) / sampleRate
+}
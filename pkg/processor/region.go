@@ -0,0 +1,193 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+)
+
+// ScreenRegion names an area of the screen that UI elements sit over.
+type ScreenRegion string
+
+const (
+	// TopRegion is the strip under a top bar such as waybar.
+	TopRegion ScreenRegion = "top"
+	// BottomRegion is the strip under a bottom bar.
+	BottomRegion ScreenRegion = "bottom"
+	// CenterRegion is the middle of the screen where windows usually sit.
+	CenterRegion ScreenRegion = "center"
+	// LeftRegion is the left half of the screen.
+	LeftRegion ScreenRegion = "left"
+	// RightRegion is the right half of the screen.
+	RightRegion ScreenRegion = "right"
+)
+
+// ScreenRegions lists every named region in reporting order.
+var ScreenRegions = []ScreenRegion{TopRegion, BottomRegion, CenterRegion, LeftRegion, RightRegion}
+
+// WallpaperFit describes how a wallpaper is scaled to the screen, using the
+// mode names of swaybg.
+type WallpaperFit string
+
+const (
+	// Fill scales the image to cover the screen, cropping the overflow
+	// equally on both sides.
+	Fill WallpaperFit = "fill"
+	// Fit scales the image to fit inside the screen, leaving bars where the
+	// aspect ratios differ.
+	Fit WallpaperFit = "fit"
+	// Stretch scales each axis independently to the screen size.
+	Stretch WallpaperFit = "stretch"
+	// Centered shows the image unscaled in the middle of the screen.
+	Centered WallpaperFit = "center"
+)
+
+// Screen is the monitor a wallpaper is displayed on. Unknown fit modes are
+// treated as Fill.
+type Screen struct {
+	Width  int
+	Height int
+	Fit    WallpaperFit
+}
+
+// transform returns the scale and offset that place image pixels of the
+// given size on the screen: screen = offset + image * scale.
+func (s Screen) transform(width, height int) (scaleX, scaleY, offsetX, offsetY float64) {
+	w, h := float64(width), float64(height)
+	sw, sh := float64(s.Width), float64(s.Height)
+
+	switch s.Fit {
+	case Stretch:
+		return sw / w, sh / h, 0, 0
+	case Centered:
+		return 1, 1, (sw - w) / 2, (sh - h) / 2
+	case Fit:
+		scale := math.Min(sw/w, sh/h)
+		return scale, scale, (sw - w*scale) / 2, (sh - h*scale) / 2
+	default:
+		scale := math.Max(sw/w, sh/h)
+		return scale, scale, (sw - w*scale) / 2, (sh - h*scale) / 2
+	}
+}
+
+// ImageRect maps a rectangle in screen coordinates to the image pixels
+// displayed there, clipped to bounds. The result is empty when none of the
+// image is visible in r.
+func (s Screen) ImageRect(bounds image.Rectangle, r image.Rectangle) image.Rectangle {
+	scaleX, scaleY, offsetX, offsetY := s.transform(bounds.Dx(), bounds.Dy())
+
+	mapped := image.Rect(
+		bounds.Min.X+int(math.Floor((float64(r.Min.X)-offsetX)/scaleX)),
+		bounds.Min.Y+int(math.Floor((float64(r.Min.Y)-offsetY)/scaleY)),
+		bounds.Min.X+int(math.Ceil((float64(r.Max.X)-offsetX)/scaleX)),
+		bounds.Min.Y+int(math.Ceil((float64(r.Max.Y)-offsetY)/scaleY)),
+	)
+	return mapped.Intersect(bounds)
+}
+
+// ScreenRect returns the area of screen covered by region. Bars are
+// processor.region_bar_height pixels tall, and the center region spans
+// processor.region_center_fraction of each screen dimension.
+func (p *Processor) ScreenRect(screen Screen, region ScreenRegion) (image.Rectangle, error) {
+	w, h := screen.Width, screen.Height
+	bar := min(max(1, p.settings.Processor.RegionBarHeight), h)
+
+	switch region {
+	case TopRegion:
+		return image.Rect(0, 0, w, bar), nil
+	case BottomRegion:
+		return image.Rect(0, h-bar, w, h), nil
+	case CenterRegion:
+		fraction := p.settings.Processor.RegionCenterFraction
+		cw := int(math.Round(float64(w) * fraction))
+		ch := int(math.Round(float64(h) * fraction))
+		return image.Rect((w-cw)/2, (h-ch)/2, (w+cw)/2, (h+ch)/2), nil
+	case LeftRegion:
+		return image.Rect(0, 0, w/2, h), nil
+	case RightRegion:
+		return image.Rect(w/2, 0, w, h), nil
+	default:
+		return image.Rectangle{}, fmt.Errorf("unknown screen region %q", region)
+	}
+}
+
+// ProcessRegion extracts a color profile from the part of img inside region,
+// in image coordinates. Regions outside the image bounds are clipped; an
+// empty result is an error.
+func (p *Processor) ProcessRegion(ctx context.Context, img image.Image, region image.Rectangle, opts ProcessOptions) (*ColorProfile, error) {
+	region = region.Intersect(img.Bounds())
+	if region.Empty() {
+		return nil, &errors.ExtractionError{
+			Stage:   "region",
+			Details: fmt.Sprintf("region does not overlap image bounds %v", img.Bounds()),
+			Err:     errors.ErrEmptyImage,
+		}
+	}
+
+	return p.ProcessImageContext(ctx, subImage(img, region), opts)
+}
+
+// ProcessRegions extracts a color profile for each named screen region from
+// the part of img displayed under it once scaled to screen. All regions are
+// processed when none are given. Regions that show none of the image, such as
+// a bar inside the letterbox of a Fit wallpaper, are omitted from the result.
+func (p *Processor) ProcessRegions(ctx context.Context, img image.Image, screen Screen, regions ...ScreenRegion) (map[ScreenRegion]*ColorProfile, error) {
+	if screen.Width <= 0 || screen.Height <= 0 {
+		return nil, fmt.Errorf("invalid screen resolution %dx%d", screen.Width, screen.Height)
+	}
+	if img.Bounds().Empty() {
+		return nil, &errors.ExtractionError{
+			Stage:   "region",
+			Details: "image has no pixels to map onto the screen",
+			Err:     errors.ErrEmptyImage,
+		}
+	}
+	if len(regions) == 0 {
+		regions = ScreenRegions
+	}
+
+	profiles := make(map[ScreenRegion]*ColorProfile, len(regions))
+	for _, region := range regions {
+		rect, err := p.ScreenRect(screen, region)
+		if err != nil {
+			return nil, err
+		}
+
+		mapped := screen.ImageRect(img.Bounds(), rect)
+		if mapped.Empty() {
+			continue
+		}
+
+		profile, err := p.ProcessRegion(ctx, img, mapped, ProcessOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", region, err)
+		}
+		profiles[region] = profile
+	}
+
+	return profiles, nil
+}
+
+// subImage returns the part of img inside r. Images that support SubImage
+// keep their concrete type so extraction retains its fast paths.
+func subImage(img image.Image, r image.Rectangle) image.Image {
+	if s, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return s.SubImage(r)
+	}
+	return croppedImage{Image: img, bounds: r}
+}
+
+// croppedImage restricts an image without SubImage support to bounds.
+type croppedImage struct {
+	image.Image
+	bounds image.Rectangle
+}
+
+func (c croppedImage) Bounds() image.Rectangle {
+	return c.bounds
+}
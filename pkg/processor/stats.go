@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"math"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// clusterStats summarizes the members of a cluster in LAB space.
+type clusterStats struct {
+	weight   float64     // Combined member weight
+	centroid formats.LAB // Weight-averaged member color
+	variance float64     // Weighted mean squared distance from the centroid
+	nearest  int         // Index of the member closest to the centroid
+	members  int
+}
+
+// newClusterStats computes the weighted centroid and variance of members.
+// Members with zero total weight are averaged equally.
+func newClusterStats(members []WeightedColor) clusterStats {
+	stats := clusterStats{members: len(members)}
+	labs := make([]formats.LAB, len(members))
+
+	for i, m := range members {
+		labs[i] = formats.RGBAToLAB(m.RGBA)
+		stats.weight += m.Weight
+	}
+
+	weightOf := func(i int) float64 {
+		if stats.weight == 0 {
+			return 1 / float64(len(members))
+		}
+		return members[i].Weight / stats.weight
+	}
+
+	for i, lab := range labs {
+		w := weightOf(i)
+		stats.centroid.L += lab.L * w
+		stats.centroid.A += lab.A * w
+		stats.centroid.B += lab.B * w
+	}
+
+	best := math.Inf(1)
+	for i, lab := range labs {
+		d := squaredLABDistance(lab, stats.centroid)
+		stats.variance += d * weightOf(i)
+		if d < best {
+			best = d
+			stats.nearest = i
+		}
+	}
+
+	return stats
+}
+
+// apply records the centroid, member count, and variance on cluster.
+func (s clusterStats) apply(cluster *ColorCluster) {
+	cluster.Centroid = formats.LABToRGBA(s.centroid)
+	cluster.Members = s.members
+	cluster.Variance = s.variance
+}
+
+func squaredLABDistance(a, b formats.LAB) float64 {
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return dl*dl + da*da + db*db
+}
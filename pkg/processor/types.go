@@ -1,7 +1,9 @@
 package processor
 
 import (
+	"fmt"
 	"image/color"
+	"strings"
 )
 
 type ThemeMode string
@@ -14,6 +16,9 @@ const (
 // ColorCluster represents a visually distinct color group with UI-relevant metadata
 type ColorCluster struct {
 	color.RGBA                   // The representative color
+	Centroid    color.RGBA       // Weight-averaged color of all members, computed in LAB
+	Members     int              // Number of weighted colors merged into the cluster
+	Variance    float64          // Weighted mean squared LAB distance of members from the centroid
 	Weight      float64          // Combined weight (0.0-1.0)
 	Lightness   float64          // Pre-calculated HSL lightness for efficiency
 	Saturation  float64          // Pre-calculated HSL saturation for efficiency
@@ -31,19 +36,34 @@ type ColorProfile struct {
 	Colors     []ColorCluster // Distinct colors, sorted by weight
 	HasColor   bool          // False if image is essentially grayscale
 	ColorCount int           // Number of distinct colors found
+
+	UniqueColors int // Number of unique quantized colors sampled
+	SampleCount  int // Number of pixels sampled
 }
 
 // WeightedColor is an internal type for processing
 type WeightedColor struct {
 	color.RGBA
-	Frequency uint32
+	Frequency uint64
 	Weight    float64
 }
 
-func NewWeightedColor(c color.RGBA, freq, total uint32) WeightedColor {
+func NewWeightedColor(c color.RGBA, freq, total uint64) WeightedColor {
 	return WeightedColor{
 		RGBA:      c,
 		Frequency: freq,
 		Weight:    float64(freq) / float64(total),
 	}
-}
\ No newline at end of file
+}
+
+// ParseThemeMode parses a case-insensitive theme mode name ("light" or "dark").
+func ParseThemeMode(name string) (ThemeMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "light":
+		return Light, nil
+	case "dark":
+		return Dark, nil
+	default:
+		return "", fmt.Errorf("invalid theme mode %q: expected light or dark", name)
+	}
+}
@@ -0,0 +1,179 @@
+package processor
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// SpatialWeighting selects how a pixel's position scales its contribution
+// to the color histogram.
+type SpatialWeighting string
+
+const (
+	// UniformWeighting counts every sampled pixel equally.
+	UniformWeighting SpatialWeighting = choices.UniformWeighting
+	// CenterWeighting favors the middle of the image with a Gaussian falloff,
+	// for wallpapers whose subject is centered.
+	CenterWeighting SpatialWeighting = choices.CenterWeighting
+	// EdgeWeighting favors pixels near the nearest border with a Gaussian
+	// falloff, for bars and panels that sit at screen edges.
+	EdgeWeighting SpatialWeighting = choices.EdgeWeighting
+	// SaliencyWeighting favors regions that contrast with the rest of the
+	// image, so a subject outweighs the sky or backdrop around it.
+	SaliencyWeighting SpatialWeighting = choices.SaliencyWeighting
+)
+
+// weightScale is the histogram contribution of a fully weighted pixel.
+// Weighted pixels contribute a fixed-point fraction of it, and at least 1 so
+// that every sampled pixel still registers. Histogram counts are uint64, so
+// totals cannot overflow however many pixels are sampled.
+const weightScale = 64
+
+// saliencySamplesPerCell bounds the samples taken along each side of a
+// saliency cell.
+const saliencySamplesPerCell = 8
+
+// pixelWeight returns the histogram contribution of the pixel at (x, y).
+type pixelWeight func(x, y int) uint32
+
+// newPixelWeight returns the weighting selected by
+// processor.spatial_weighting. Uniform weighting, and the fallback for unknown
+// modes in settings that skip Settings.Validate, counts every pixel as 1 so
+// histogram totals equal sample counts. Only saliency weighting reads pixels,
+// and it stops with the context error once ctx is cancelled.
+func (p *Processor) newPixelWeight(ctx context.Context, img image.Image, read func(x, y int) color.NRGBA, alpha alphaResolver) (pixelWeight, error) {
+	bounds := img.Bounds()
+
+	switch SpatialWeighting(p.settings.Processor.SpatialWeighting) {
+	case CenterWeighting:
+		sigma := p.settings.Processor.CenterSigma
+		cols := axisWeights(bounds.Min.X, bounds.Max.X, func(t float64) float64 {
+			return gaussian(t-0.5, sigma)
+		})
+		rows := axisWeights(bounds.Min.Y, bounds.Max.Y, func(t float64) float64 {
+			return gaussian(t-0.5, sigma)
+		})
+		return func(x, y int) uint32 {
+			return scaleWeight(cols[x-bounds.Min.X] * rows[y-bounds.Min.Y])
+		}, nil
+	case EdgeWeighting:
+		sigma := p.settings.Processor.EdgeSigma
+		border := func(t float64) float64 {
+			return gaussian(math.Min(t, 1-t), sigma)
+		}
+		cols := axisWeights(bounds.Min.X, bounds.Max.X, border)
+		rows := axisWeights(bounds.Min.Y, bounds.Max.Y, border)
+		return func(x, y int) uint32 {
+			return scaleWeight(math.Max(cols[x-bounds.Min.X], rows[y-bounds.Min.Y]))
+		}, nil
+	case SaliencyWeighting:
+		return p.saliencyWeight(ctx, bounds, read, alpha)
+	default:
+		return func(x, y int) uint32 { return 1 }, nil
+	}
+}
+
+// saliencyWeight weights pixels by the global contrast of the grid cell they
+// fall in: the sample-weighted mean LAB distance from the cell's average color
+// to every other cell's, normalized so the most distinctive cell has weight 1.
+// Large uniform areas are similar to much of the image and score low, while a
+// subject that differs from its surroundings scores high. The normalized
+// contrast is squared to sharpen the map, so a subject can outweigh a
+// backdrop several times its area.
+func (p *Processor) saliencyWeight(ctx context.Context, bounds image.Rectangle, read func(x, y int) color.NRGBA, alpha alphaResolver) (pixelWeight, error) {
+	width, height := bounds.Dx(), bounds.Dy()
+	grid := max(1, p.settings.Processor.SaliencyGrid)
+	cols, rows := min(grid, width), min(grid, height)
+	cellW := (width + cols - 1) / cols
+	cellH := (height + rows - 1) / rows
+	stride := max(1, min(cellW, cellH)/saliencySamplesPerCell)
+
+	cells := make([]colorAccumulator, cols*rows)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stride {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		row := (y - bounds.Min.Y) / cellH
+		for x := bounds.Min.X; x < bounds.Max.X; x += stride {
+			c, ok := alpha.resolve(read(x, y))
+			if !ok {
+				continue
+			}
+			cells[row*cols+(x-bounds.Min.X)/cellW].add(c, 1)
+		}
+	}
+
+	labs := make([]formats.LAB, len(cells))
+	for i := range cells {
+		if cells[i].count > 0 {
+			labs[i] = formats.RGBAToLAB(cells[i].average())
+		}
+	}
+
+	contrast := make([]float64, len(cells))
+	var highest float64
+	for i := range cells {
+		if cells[i].count == 0 {
+			continue
+		}
+		var sum, total float64
+		for j := range cells {
+			if cells[j].count == 0 {
+				continue
+			}
+			n := float64(cells[j].count)
+			sum += n * math.Sqrt(squaredLABDistance(labs[i], labs[j]))
+			total += n
+		}
+		contrast[i] = sum / total
+		highest = math.Max(highest, contrast[i])
+	}
+
+	weights := make([]uint32, len(cells))
+	for i := range weights {
+		if highest == 0 {
+			// A single flat color has no salient region
+			weights[i] = weightScale
+			continue
+		}
+		c := contrast[i] / highest
+		weights[i] = scaleWeight(c * c)
+	}
+
+	return func(x, y int) uint32 {
+		return weights[(y-bounds.Min.Y)/cellH*cols+(x-bounds.Min.X)/cellW]
+	}, nil
+}
+
+// axisWeights evaluates f at the center of each pixel along one axis, with
+// positions normalized to [0, 1].
+func axisWeights(start, end int, f func(t float64) float64) []float64 {
+	n := end - start
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = f((float64(i) + 0.5) / float64(n))
+	}
+	return weights
+}
+
+// gaussian is an unnormalized Gaussian with a peak of 1 at d = 0.
+func gaussian(d, sigma float64) float64 {
+	if sigma <= 0 {
+		if d == 0 {
+			return 1
+		}
+		return 0
+	}
+	return math.Exp(-d * d / (2 * sigma * sigma))
+}
+
+// scaleWeight converts a weight in [0, 1] to a histogram contribution.
+func scaleWeight(w float64) uint32 {
+	return max(1, uint32(math.Round(w*weightScale)))
+}
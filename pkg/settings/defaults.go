@@ -24,24 +24,70 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("formats.quantization_bits", 5) // 32 levels per channel
 
 	// Chromatic settings
-	v.SetDefault("chromatic.color_merge_threshold", 15.0)       // Delta-E threshold for color similarity
-	v.SetDefault("chromatic.neutral_threshold", 0.1)           // 10% saturation threshold for neutrals
-	v.SetDefault("chromatic.neutral_lightness_threshold", 0.08) // 8% lightness difference for neutral clustering
-	v.SetDefault("chromatic.dark_lightness_max", 0.3)           // 30% maximum lightness for dark classification
-	v.SetDefault("chromatic.light_lightness_min", 0.7)          // 70% minimum lightness for light classification
-	v.SetDefault("chromatic.muted_saturation_max", 0.3)         // 30% maximum saturation for muted classification
-	v.SetDefault("chromatic.vibrant_saturation_min", 0.7)       // 70% minimum saturation for vibrant classification
+	v.SetDefault("chromatic.distance_metric", "cie76")            // CIE76 LAB distance; each metric has its own merge threshold
+	v.SetDefault("chromatic.color_merge_threshold", 15.0)         // CIE76 Delta-E threshold for color similarity
+	v.SetDefault("chromatic.cie94_merge_threshold", 7.5)          // CIE94 equivalent of a CIE76 distance of 15
+	v.SetDefault("chromatic.ciede2000_merge_threshold", 7.0)      // CIEDE2000 equivalent of a CIE76 distance of 15
+	v.SetDefault("chromatic.neutral_threshold", 0.1)              // 10% saturation threshold for neutrals
+	v.SetDefault("chromatic.neutral_lightness_threshold", 0.08)   // 8% lightness difference for neutral clustering
+	v.SetDefault("chromatic.dark_lightness_max", 0.3)             // 30% maximum lightness for dark classification
+	v.SetDefault("chromatic.light_lightness_min", 0.7)            // 70% minimum lightness for light classification
+	v.SetDefault("chromatic.muted_saturation_max", 0.3)           // 30% maximum saturation for muted classification
+	v.SetDefault("chromatic.vibrant_saturation_min", 0.7)         // 70% minimum saturation for vibrant classification
+	v.SetDefault("chromatic.analogous_spread", 30.0)              // 30° between analogous neighbors
+	v.SetDefault("chromatic.split_complementary_angle", 30.0)     // 30° either side of the complement
+	v.SetDefault("chromatic.monochromatic_lightness_step", 0.15)  // 15% lightness step between monochromatic variants
+	v.SetDefault("chromatic.harmony_hue_tolerance", 20.0)         // 20° maximum distance for a hue to match a harmony target
+	v.SetDefault("chromatic.deficiency_distance_threshold", 10.0) // CIEDE2000 distance below which simulated colors are indistinguishable
 
 	// Processing layer settings
-	v.SetDefault("processor.min_frequency", 0.0001)              // 0.01% minimum frequency
-	v.SetDefault("processor.min_cluster_weight", 0.005)          // 0.5% minimum cluster weight
-	v.SetDefault("processor.min_ui_color_weight", 0.01)          // 1% minimum for UI inclusion
-	v.SetDefault("processor.max_ui_colors", 20)                  // Maximum colors for UI palette
-	v.SetDefault("processor.pure_black_threshold", 0.01)         // 1% lightness threshold for pure black
-	v.SetDefault("processor.pure_white_threshold", 0.99)         // 99% lightness threshold for pure white
-	v.SetDefault("processor.light_theme_threshold", 0.5)         // 50% lightness threshold for light theme
-	v.SetDefault("processor.theme_mode_max_clusters", 5)         // Maximum clusters to consider for theme mode
-	v.SetDefault("processor.significant_color_threshold", 0.1)   // 10% weight threshold for significant color content
+	v.SetDefault("processor.min_frequency", 0.0001)            // 0.01% minimum frequency
+	v.SetDefault("processor.max_samples", 1000000)             // Bound extraction cost at any resolution
+	v.SetDefault("processor.extractor", "histogram")           // Quantized frequency histogram; median-cut or octree for photographs
+	v.SetDefault("processor.extractor_colors", 64)             // Target colors for median-cut and octree
+	v.SetDefault("processor.min_alpha", 16)                    // Skip nearly transparent pixels
+	v.SetDefault("processor.alpha_matte", "")                  // Count translucent pixels by their straight color
+	v.SetDefault("processor.spatial_weighting", "uniform")     // Count every pixel equally; center, edge, or saliency to favor regions
+	v.SetDefault("processor.center_sigma", 0.25)               // Center weight falls to 61% a quarter of the image from the middle
+	v.SetDefault("processor.edge_sigma", 0.1)                  // Edge weight falls to 61% a tenth of the image from the border
+	v.SetDefault("processor.saliency_grid", 16)                // 16x16 cells for saliency contrast
+	v.SetDefault("processor.region_bar_height", 32)            // 32px strip under a top or bottom bar
+	v.SetDefault("processor.region_center_fraction", 0.5)      // Center region spans the middle half of the screen
+	v.SetDefault("processor.min_cluster_weight", 0.005)        // 0.5% minimum cluster weight
+	v.SetDefault("processor.clustering_algorithm", "greedy")   // Greedy single-pass merge; kmeans for weighted centroids
+	v.SetDefault("processor.kmeans_clusters", 12)              // Maximum clusters for k-means
+	v.SetDefault("processor.kmeans_max_iterations", 25)        // Maximum k-means refinement passes
+	v.SetDefault("processor.kmeans_color_space", "oklab")      // Perceptual space for k-means: lab or oklab
+	v.SetDefault("processor.seed", 1)                          // Random seed for reproducible seeding and sampling
+	v.SetDefault("processor.min_ui_color_weight", 0.01)        // 1% minimum for UI inclusion
+	v.SetDefault("processor.max_ui_colors", 20)                // Maximum colors for UI palette
+	v.SetDefault("processor.pure_black_threshold", 0.01)       // 1% lightness threshold for pure black
+	v.SetDefault("processor.pure_white_threshold", 0.99)       // 99% lightness threshold for pure white
+	v.SetDefault("processor.light_theme_threshold", 0.5)       // 50% lightness threshold for light theme
+	v.SetDefault("processor.theme_mode_max_clusters", 5)       // Maximum clusters to consider for theme mode
+	v.SetDefault("processor.significant_color_threshold", 0.1) // 10% weight threshold for significant color content
+
+	// Generation layer settings
+	v.SetDefault("palette.dark_background_lightness", 0.12)   // 12% lightness for synthesized dark backgrounds
+	v.SetDefault("palette.light_background_lightness", 0.94)  // 94% lightness for synthesized light backgrounds
+	v.SetDefault("palette.dark_foreground_lightness", 0.88)   // 88% lightness for foregrounds on dark backgrounds
+	v.SetDefault("palette.light_foreground_lightness", 0.18)  // 18% lightness for foregrounds on light backgrounds
+	v.SetDefault("palette.background_saturation_max", 0.35)   // 35% maximum saturation for background/foreground
+	v.SetDefault("palette.accent_hue_separation", 30.0)       // 30° minimum hue distance between accents
+	v.SetDefault("palette.scheme_hue_tolerance", 20.0)        // 20° maximum distance for a cluster to fill a scheme accent
+	v.SetDefault("palette.scheme_min_confidence", 0.5)        // 50% minimum confidence to detect a harmony scheme
+	v.SetDefault("palette.dim_foreground_mix", 0.35)          // 35% blend of foreground toward background
+	v.SetDefault("palette.selection_mix", 0.6)                // 60% blend of primary toward background
+	v.SetDefault("palette.text_contrast_level", "AA")         // WCAG AA (4.5:1) for foreground text
+	v.SetDefault("palette.accent_contrast_level", "AA-large") // WCAG AA large (3:1) for accents and terminal colors
+	v.SetDefault("palette.border_contrast_level", "non-text") // WCAG non-text (3:1) for borders
+	v.SetDefault("palette.ansi_hue_tolerance", 25.0)          // 25° maximum distance to snap a cluster to an ANSI slot
+	v.SetDefault("palette.ansi_saturation_min", 0.35)         // 35% minimum saturation for ANSI chromatic colors
+	v.SetDefault("palette.ansi_lightness_min", 0.45)          // 45% minimum lightness for ANSI chromatic colors
+	v.SetDefault("palette.ansi_lightness_max", 0.7)           // 70% maximum lightness for ANSI chromatic colors
+	v.SetDefault("palette.ansi_bright_lightness_delta", 0.1)  // 10% lightness increase for bright variants
+
+	v.SetDefault("theme.themes_dir", "~/.config/omarchy/themes") // Omarchy user themes directory
 
 	// Global settings
 	v.SetDefault("default_dark", "#1a1a1a")
@@ -8,6 +8,10 @@
 //  4. Workspace config: ./omarchy-theme-gen.json
 //  5. Environment variables: OMARCHY_THEME_GEN_*
 //
+// Loaded settings are validated: an unknown name for an enumerated setting,
+// such as a palette contrast level or the distance metric, is reported as an
+// error naming the value and the allowed names from pkg/choices.
+//
 // Usage:
 //
 //	settings, err := settings.Load()
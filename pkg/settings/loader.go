@@ -59,6 +59,10 @@ func Load() (*Settings, error) {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
 
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &settings, nil
 }
 
@@ -67,6 +71,9 @@ func LoadWithViper(v *viper.Viper) (*Settings, error) {
 	if err := v.Unmarshal(&settings); err != nil {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
 	return &settings, nil
 }
 
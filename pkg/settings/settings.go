@@ -15,6 +15,10 @@ type Settings struct {
 	// Processing layer settings
 	Processor ProcessorSettings `mapstructure:"processor"`
 
+	// Generation layer settings
+	Palette PaletteSettings `mapstructure:"palette"`
+	Theme   ThemeSettings   `mapstructure:"theme"`
+
 	// Global settings
 	DefaultDark  string `mapstructure:"default_dark"`  // Fallback dark color
 	DefaultLight string `mapstructure:"default_light"` // Fallback light color
@@ -32,21 +36,53 @@ type FormatsSettings struct {
 }
 
 type ChromaticSettings struct {
-	ColorMergeThreshold       float64 `mapstructure:"color_merge_threshold"`       // LAB distance for color similarity
-	NeutralThreshold          float64 `mapstructure:"neutral_threshold"`          // Saturation threshold for neutral colors
+	DistanceMetric            string  `mapstructure:"distance_metric"`             // Color difference formula: cie76, cie94, or ciede2000
+	ColorMergeThreshold       float64 `mapstructure:"color_merge_threshold"`       // CIE76 distance for color similarity
+	CIE94MergeThreshold       float64 `mapstructure:"cie94_merge_threshold"`       // CIE94 distance for color similarity
+	CIEDE2000MergeThreshold   float64 `mapstructure:"ciede2000_merge_threshold"`   // CIEDE2000 distance for color similarity
+	NeutralThreshold          float64 `mapstructure:"neutral_threshold"`           // Saturation threshold for neutral colors
 	NeutralLightnessThreshold float64 `mapstructure:"neutral_lightness_threshold"` // Lightness difference threshold for neutral clustering
 	DarkLightnessMax          float64 `mapstructure:"dark_lightness_max"`          // Maximum lightness for dark classification
 	LightLightnessMin         float64 `mapstructure:"light_lightness_min"`         // Minimum lightness for light classification
 	MutedSaturationMax        float64 `mapstructure:"muted_saturation_max"`        // Maximum saturation for muted classification
 	VibrantSaturationMin      float64 `mapstructure:"vibrant_saturation_min"`      // Minimum saturation for vibrant classification
+
+	// Harmony generation
+	AnalogousSpread            float64 `mapstructure:"analogous_spread"`             // Hue offset in degrees between analogous neighbors
+	SplitComplementaryAngle    float64 `mapstructure:"split_complementary_angle"`    // Hue offset in degrees from the complement for split-complementary
+	MonochromaticLightnessStep float64 `mapstructure:"monochromatic_lightness_step"` // Lightness step between monochromatic variants
+	HarmonyHueTolerance        float64 `mapstructure:"harmony_hue_tolerance"`        // Maximum hue distance in degrees for a hue to match a harmony target
+
+	// Color vision deficiency
+	DeficiencyDistanceThreshold float64 `mapstructure:"deficiency_distance_threshold"` // CIEDE2000 distance below which colors are indistinguishable under a simulated deficiency
 }
 
 type ProcessorSettings struct {
 	// Color extraction
-	MinFrequency float64 `mapstructure:"min_frequency"` // Minimum frequency to consider
+	MinFrequency    float64 `mapstructure:"min_frequency"`    // Minimum frequency to consider
+	MaxSamples      int     `mapstructure:"max_samples"`      // Maximum pixels sampled per image; 0 samples every pixel
+	Extractor       string  `mapstructure:"extractor"`        // Color extractor: histogram, median-cut, or octree
+	ExtractorColors int     `mapstructure:"extractor_colors"` // Target number of colors for median-cut and octree extractors
+	MinAlpha        int     `mapstructure:"min_alpha"`        // Minimum pixel alpha (0-255) to count during extraction
+	AlphaMatte      string  `mapstructure:"alpha_matte"`      // Hex color to composite translucent pixels over; empty keeps straight colors
+
+	// Spatial weighting
+	SpatialWeighting string  `mapstructure:"spatial_weighting"` // Pixel weighting by position: uniform, center, edge, or saliency
+	CenterSigma      float64 `mapstructure:"center_sigma"`      // Gaussian spread of center weighting as a fraction of width and height
+	EdgeSigma        float64 `mapstructure:"edge_sigma"`        // Gaussian falloff of edge weighting from the nearest border as a fraction of width and height
+	SaliencyGrid     int     `mapstructure:"saliency_grid"`     // Cells per side of the saliency contrast grid
+
+	// Screen regions
+	RegionBarHeight      int     `mapstructure:"region_bar_height"`      // Screen pixels covered by the top and bottom bar regions
+	RegionCenterFraction float64 `mapstructure:"region_center_fraction"` // Fraction of each screen dimension spanned by the center region
 
 	// Clustering
-	MinClusterWeight float64 `mapstructure:"min_cluster_weight"` // Minimum weight to keep cluster
+	MinClusterWeight    float64 `mapstructure:"min_cluster_weight"`    // Minimum weight to keep cluster
+	ClusteringAlgorithm string  `mapstructure:"clustering_algorithm"`  // Clustering algorithm: greedy or kmeans
+	KMeansClusters      int     `mapstructure:"kmeans_clusters"`       // Number of clusters (k) for k-means
+	KMeansMaxIterations int     `mapstructure:"kmeans_max_iterations"` // Maximum k-means iterations before stopping
+	KMeansColorSpace    string  `mapstructure:"kmeans_color_space"`    // Color space for k-means distances and centroids: lab or oklab
+	Seed                int64   `mapstructure:"seed"`                  // Random seed for reproducible results
 
 	// UI filtering
 	MinUIColorWeight         float64 `mapstructure:"min_ui_color_weight"`         // Minimum weight for UI inclusion
@@ -60,6 +96,40 @@ type ProcessorSettings struct {
 	SignificantColorThreshold float64 `mapstructure:"significant_color_threshold"` // Weight threshold for significant color content
 }
 
+type PaletteSettings struct {
+	// Role synthesis
+	DarkBackgroundLightness  float64 `mapstructure:"dark_background_lightness"`  // Lightness for synthesized dark backgrounds
+	LightBackgroundLightness float64 `mapstructure:"light_background_lightness"` // Lightness for synthesized light backgrounds
+	DarkForegroundLightness  float64 `mapstructure:"dark_foreground_lightness"`  // Lightness for synthesized foregrounds on dark backgrounds
+	LightForegroundLightness float64 `mapstructure:"light_foreground_lightness"` // Lightness for synthesized foregrounds on light backgrounds
+	BackgroundSaturationMax  float64 `mapstructure:"background_saturation_max"`  // Maximum saturation for background and foreground roles
+
+	// Accent selection
+	AccentHueSeparation float64 `mapstructure:"accent_hue_separation"` // Minimum hue distance in degrees between accents
+	SchemeHueTolerance  float64 `mapstructure:"scheme_hue_tolerance"`  // Maximum hue distance in degrees for an extracted cluster to fill a scheme accent
+	SchemeMinConfidence float64 `mapstructure:"scheme_min_confidence"` // Minimum harmony confidence for DetectScheme to report a harmony scheme
+
+	// Derived roles
+	DimForegroundMix float64 `mapstructure:"dim_foreground_mix"` // Foreground blend toward background for dim foreground
+	SelectionMix     float64 `mapstructure:"selection_mix"`      // Primary blend toward background for selection
+
+	// Contrast enforcement
+	TextContrastLevel   string `mapstructure:"text_contrast_level"`   // WCAG level for foreground text on background
+	AccentContrastLevel string `mapstructure:"accent_contrast_level"` // WCAG level for accents, dim text, and terminal colors on background
+	BorderContrastLevel string `mapstructure:"border_contrast_level"` // WCAG level for borders on background
+
+	// ANSI terminal colors
+	ANSIHueTolerance         float64 `mapstructure:"ansi_hue_tolerance"`          // Maximum hue distance in degrees to snap a cluster to an ANSI slot
+	ANSISaturationMin        float64 `mapstructure:"ansi_saturation_min"`         // Minimum saturation for ANSI chromatic colors
+	ANSILightnessMin         float64 `mapstructure:"ansi_lightness_min"`          // Minimum lightness for ANSI chromatic colors
+	ANSILightnessMax         float64 `mapstructure:"ansi_lightness_max"`          // Maximum lightness for ANSI chromatic colors
+	ANSIBrightLightnessDelta float64 `mapstructure:"ansi_bright_lightness_delta"` // Lightness increase for bright variants
+}
+
+type ThemeSettings struct {
+	ThemesDir string `mapstructure:"themes_dir"` // Directory containing Omarchy themes (supports ~ and $VAR expansion)
+}
+
 func WithSettings(ctx context.Context, s *Settings) context.Context {
 	return context.WithValue(ctx, settingsKey, s)
 }
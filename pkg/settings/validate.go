@@ -0,0 +1,46 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
+)
+
+// Validate reports settings whose values are outside their allowed range. The
+// allowed names come from pkg/choices, the same lists the interpreting
+// packages build their typed constants from. Names match regardless of case
+// and surrounding whitespace, and accepted values are rewritten to their
+// canonical spelling.
+func (s *Settings) Validate() error {
+	enums := []struct {
+		key   string
+		value *string
+		names []string
+	}{
+		{"chromatic.distance_metric", &s.Chromatic.DistanceMetric, choices.DistanceMetrics},
+		{"processor.extractor", &s.Processor.Extractor, choices.Extractors},
+		{"processor.spatial_weighting", &s.Processor.SpatialWeighting, choices.SpatialWeightings},
+		{"processor.clustering_algorithm", &s.Processor.ClusteringAlgorithm, choices.ClusteringAlgorithms},
+		{"processor.kmeans_color_space", &s.Processor.KMeansColorSpace, choices.KMeansColorSpaces},
+		{"palette.text_contrast_level", &s.Palette.TextContrastLevel, choices.AccessibilityLevels},
+		{"palette.accent_contrast_level", &s.Palette.AccentContrastLevel, choices.AccessibilityLevels},
+		{"palette.border_contrast_level", &s.Palette.BorderContrastLevel, choices.AccessibilityLevels},
+	}
+
+	for _, e := range enums {
+		name, ok := choices.Lookup(e.names, *e.value)
+		if !ok {
+			return fmt.Errorf("invalid %s %q: expected one of %s",
+				e.key, *e.value, strings.Join(e.names, ", "))
+		}
+		*e.value = name
+	}
+
+	if s.Chromatic.HarmonyHueTolerance <= 0 {
+		return fmt.Errorf("invalid chromatic.harmony_hue_tolerance %g: expected a positive number of degrees",
+			s.Chromatic.HarmonyHueTolerance)
+	}
+
+	return nil
+}
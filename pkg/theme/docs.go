@@ -0,0 +1,60 @@
+// Package theme generates Omarchy theme directories from semantic palettes.
+// It renders each component configuration file with the color encoding that
+// component expects and copies the source wallpaper into backgrounds/.
+//
+// Generated Structure:
+//
+//	theme-name/
+//	├── alacritty.toml
+//	├── btop.theme
+//	├── hyprland.conf
+//	├── hyprlock.conf
+//	├── mako.ini
+//	├── neovim.lua
+//	├── swayosd.css
+//	├── walker.css
+//	├── waybar.css
+//	├── theme-gen.json      # Generator metadata
+//	├── backgrounds/
+//	│   └── [source image]
+//	└── light.mode          # Light themes only
+//
+// Atomic Output:
+//
+// Themes are rendered into a hidden temporary directory beside the target and
+// renamed into place only after every file is written. Replacing an existing
+// theme moves the old directory aside first and restores it if the final
+// rename fails, so a failed generation never leaves a broken theme behind.
+//
+// Metadata:
+//
+// theme-gen.json captures the full ColorProfile, the image analysis
+// (including the detected harmony scheme), the generation choices, and user
+// overrides. Regenerate rebuilds a theme in place from this file without
+// re-reading the source image, pinning any non-null overrides and optionally
+// changing its scheme or mode. Clone does the same into a new theme
+// directory, leaving the source untouched. Older schema versions are migrated
+// step by step on load.
+//
+// Usage:
+//
+//	settings := settings.DefaultSettings()
+//	writer := theme.New(settings)
+//
+//	path, err := writer.Write("mytheme", profile, pal, "wallpaper.jpg")
+//	if err != nil {
+//	    return err
+//	}
+//
+//	// After editing overrides in theme-gen.json
+//	path, err = writer.Regenerate("mytheme", theme.Changes{})
+//
+//	// Derive a light triadic variant
+//	path, err = writer.Clone("mytheme", "mytheme-light", theme.Changes{
+//	    Scheme: palette.SchemeTriadic,
+//	    Mode:   processor.Light,
+//	})
+//
+// The package follows the settings-as-methods pattern; the output location is
+// read from settings.Theme.ThemesDir.
+package theme
@@ -0,0 +1,360 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+)
+
+const (
+	// MetadataFile is the name of the generator metadata file in a theme directory.
+	MetadataFile = "theme-gen.json"
+
+	// MetadataVersion is the current theme-gen.json schema version.
+	// 1.0.0 is the schema documented in OMARCHY.md; 1.1.0 adds full cluster
+	// data so the ColorProfile can be reconstructed without the source image;
+	// 1.2.0 adds the detected harmony scheme to the analysis.
+	MetadataVersion = "1.2.0"
+
+	// monochromaticHueVariance is the maximum hue standard deviation in degrees
+	// among chromatic clusters for an image to be considered monochromatic.
+	monochromaticHueVariance = 15.0
+)
+
+// Metadata is the theme-gen.json document written to every generated theme.
+// All colors are stored as lowercase #rrggbbaa strings.
+type Metadata struct {
+	Version         string          `json:"version"`
+	SourceImage     string          `json:"source_image"` // Relative to the theme directory
+	ExtractedColors ExtractedColors `json:"extracted_colors"`
+	Analysis        Analysis        `json:"analysis"`
+	Generation      Generation      `json:"generation"`
+	Overrides       Overrides       `json:"overrides"`
+}
+
+// ExtractedColors records the clusters extracted from the source image.
+type ExtractedColors struct {
+	Dominant    string              `json:"dominant"`
+	Palette     []string            `json:"palette"`               // Cluster colors, sorted by weight
+	UniqueCount int                 `json:"unique_count"`          // Unique quantized colors sampled
+	SampleCount int                 `json:"sample_count"`          // Pixels sampled
+	Fingerprint string              `json:"fingerprint,omitempty"` // processor.ColorProfile fingerprint of the extraction
+	CoverageMap map[string]Coverage `json:"coverage_map"`
+	Clusters    []Cluster           `json:"clusters"`
+}
+
+// Coverage describes how much of the sampled image a cluster covers.
+type Coverage struct {
+	Pixels     int     `json:"pixels"`
+	Percentage float64 `json:"percentage"`
+}
+
+// Cluster is the stored form of a processor.ColorCluster.
+type Cluster struct {
+	Color      string  `json:"color"`
+	Centroid   string  `json:"centroid,omitempty"` // Weight-averaged member color; defaults to Color
+	Members    int     `json:"members,omitempty"`
+	Variance   float64 `json:"variance,omitempty"` // Weighted mean squared LAB distance from the centroid
+	Weight     float64 `json:"weight"`
+	Lightness  float64 `json:"lightness"`
+	Saturation float64 `json:"saturation"`
+	Hue        float64 `json:"hue"`
+	IsNeutral  bool    `json:"is_neutral"`
+	IsDark     bool    `json:"is_dark"`
+	IsLight    bool    `json:"is_light"`
+	IsMuted    bool    `json:"is_muted"`
+	IsVibrant  bool    `json:"is_vibrant"`
+}
+
+// Analysis summarizes image characteristics derived from the extracted clusters.
+type Analysis struct {
+	DetectedMode        string  `json:"detected_mode"` // Mode inferred from the image
+	IsGrayscale         bool    `json:"is_grayscale"`
+	IsMonochromatic     bool    `json:"is_monochromatic"`
+	AverageLuminance    float64 `json:"average_luminance"`    // Weighted WCAG relative luminance
+	PerceptualDiversity float64 `json:"perceptual_diversity"` // Weighted mean LAB distance between clusters, normalized to 0-1
+	DetectedScheme      string  `json:"detected_scheme"`      // Harmony scheme the image exhibits, or extracted below the confidence threshold
+	SchemeConfidence    float64 `json:"scheme_confidence"`    // Confidence of the best matching harmony, 0-1
+}
+
+// Generation records the choices and resulting key roles of the last render.
+type Generation struct {
+	Mode       string    `json:"mode"`
+	Scheme     string    `json:"scheme"`
+	Primary    string    `json:"primary"`
+	Background string    `json:"background"`
+	Foreground string    `json:"foreground"`
+	Accent1    string    `json:"accent1"` // Primary accent
+	Accent2    string    `json:"accent2"` // Secondary accent
+	Accent3    string    `json:"accent3"` // Tertiary accent
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Overrides pins semantic roles to user-chosen colors across re-renders.
+// Null fields are generated from the extracted colors.
+type Overrides struct {
+	Background *string `json:"background"`
+	Foreground *string `json:"foreground"`
+	Primary    *string `json:"primary"`
+	Secondary  *string `json:"secondary"`
+	Tertiary   *string `json:"tertiary"`
+}
+
+// Palette parses the non-null overrides into palette overrides.
+func (o Overrides) Palette() (palette.Overrides, error) {
+	var result palette.Overrides
+
+	fields := []struct {
+		name   string
+		value  *string
+		target **color.RGBA
+	}{
+		{"background", o.Background, &result.Background},
+		{"foreground", o.Foreground, &result.Foreground},
+		{"primary", o.Primary, &result.Primary},
+		{"secondary", o.Secondary, &result.Secondary},
+		{"tertiary", o.Tertiary, &result.Tertiary},
+	}
+
+	for _, f := range fields {
+		if f.value == nil {
+			continue
+		}
+		c, err := formats.ParseHex(*f.value)
+		if err != nil {
+			return palette.Overrides{}, fmt.Errorf("%w: override %s: %v", errors.ErrInvalidMetadata, f.name, err)
+		}
+		*f.target = &c
+	}
+
+	return result, nil
+}
+
+// NewMetadata captures a ColorProfile and the palette rendered from it.
+// sourceImage is the original image path; the stored path is relative to the
+// theme directory.
+func NewMetadata(profile *processor.ColorProfile, pal *palette.SemanticPalette, sourceImage string) *Metadata {
+	scheme := pal.Scheme
+	if scheme == "" {
+		scheme = palette.SchemeExtracted
+	}
+
+	return &Metadata{
+		Version:         MetadataVersion,
+		SourceImage:     filepath.ToSlash(filepath.Join(BackgroundsDir, filepath.Base(sourceImage))),
+		ExtractedColors: extractedColors(profile),
+		Analysis:        analyze(profile),
+		Generation: Generation{
+			Mode:       modeName(pal.Mode),
+			Scheme:     string(scheme),
+			Primary:    hexa(pal.Primary),
+			Background: hexa(pal.Background),
+			Foreground: hexa(pal.Foreground),
+			Accent1:    hexa(pal.Primary),
+			Accent2:    hexa(pal.Secondary),
+			Accent3:    hexa(pal.Tertiary),
+			Timestamp:  time.Now().UTC().Truncate(time.Second),
+		},
+	}
+}
+
+// Marshal encodes the metadata as indented JSON.
+func (m *Metadata) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Profile reconstructs the ColorProfile captured by the metadata. The profile
+// mode is the mode detected from the image, not the generated theme mode.
+func (m *Metadata) Profile() (*processor.ColorProfile, error) {
+	extracted := m.ExtractedColors
+	if len(extracted.Clusters) == 0 {
+		return nil, fmt.Errorf("%w: no extracted clusters", errors.ErrInvalidMetadata)
+	}
+
+	mode, err := parseMode(m.Analysis.DetectedMode)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := make([]processor.ColorCluster, len(extracted.Clusters))
+	for i, c := range extracted.Clusters {
+		rgba, err := formats.ParseHex(c.Color)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cluster %d: %v", errors.ErrInvalidMetadata, i, err)
+		}
+		centroid := rgba
+		if c.Centroid != "" {
+			if centroid, err = formats.ParseHex(c.Centroid); err != nil {
+				return nil, fmt.Errorf("%w: cluster %d centroid: %v", errors.ErrInvalidMetadata, i, err)
+			}
+		}
+		colors[i] = processor.ColorCluster{
+			RGBA:       rgba,
+			Centroid:   centroid,
+			Members:    c.Members,
+			Variance:   c.Variance,
+			Weight:     c.Weight,
+			Lightness:  c.Lightness,
+			Saturation: c.Saturation,
+			Hue:        c.Hue,
+			IsNeutral:  c.IsNeutral,
+			IsDark:     c.IsDark,
+			IsLight:    c.IsLight,
+			IsMuted:    c.IsMuted,
+			IsVibrant:  c.IsVibrant,
+		}
+	}
+
+	return &processor.ColorProfile{
+		Mode:         mode,
+		Colors:       colors,
+		HasColor:     !m.Analysis.IsGrayscale,
+		ColorCount:   len(colors),
+		UniqueColors: extracted.UniqueCount,
+		SampleCount:  extracted.SampleCount,
+	}, nil
+}
+
+// Mode returns the generated theme mode.
+func (m *Metadata) Mode() (processor.ThemeMode, error) {
+	return parseMode(m.Generation.Mode)
+}
+
+// Scheme returns the generated accent scheme.
+func (m *Metadata) Scheme() (palette.Scheme, error) {
+	scheme, err := palette.ParseScheme(m.Generation.Scheme)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errors.ErrInvalidMetadata, err)
+	}
+	return scheme, nil
+}
+
+// extractedColors converts profile clusters to their stored form.
+func extractedColors(profile *processor.ColorProfile) ExtractedColors {
+	extracted := ExtractedColors{
+		Palette:     make([]string, len(profile.Colors)),
+		UniqueCount: profile.UniqueColors,
+		SampleCount: profile.SampleCount,
+		Fingerprint: profile.Fingerprint(),
+		CoverageMap: make(map[string]Coverage, len(profile.Colors)),
+		Clusters:    make([]Cluster, len(profile.Colors)),
+	}
+
+	for i, c := range profile.Colors {
+		hex := hexa(c.RGBA)
+		extracted.Palette[i] = hex
+
+		coverage := extracted.CoverageMap[hex]
+		coverage.Pixels += int(math.Round(c.Weight * float64(profile.SampleCount)))
+		coverage.Percentage = math.Round((coverage.Percentage+c.Weight*100)*100) / 100
+		extracted.CoverageMap[hex] = coverage
+
+		extracted.Clusters[i] = storedCluster(c)
+	}
+
+	if len(extracted.Palette) > 0 {
+		extracted.Dominant = extracted.Palette[0]
+	}
+
+	return extracted
+}
+
+// storedCluster converts a processor cluster to its stored form.
+func storedCluster(c processor.ColorCluster) Cluster {
+	var centroid string
+	if c.Centroid != (color.RGBA{}) {
+		centroid = hexa(c.Centroid)
+	}
+	return Cluster{
+		Color:      hexa(c.RGBA),
+		Centroid:   centroid,
+		Members:    c.Members,
+		Variance:   c.Variance,
+		Weight:     c.Weight,
+		Lightness:  c.Lightness,
+		Saturation: c.Saturation,
+		Hue:        c.Hue,
+		IsNeutral:  c.IsNeutral,
+		IsDark:     c.IsDark,
+		IsLight:    c.IsLight,
+		IsMuted:    c.IsMuted,
+		IsVibrant:  c.IsVibrant,
+	}
+}
+
+// analyze derives the image analysis summary from the profile clusters.
+func analyze(profile *processor.ColorProfile) Analysis {
+	analysis := Analysis{
+		DetectedMode: modeName(profile.Mode),
+		IsGrayscale:  !profile.HasColor,
+	}
+
+	var luminance, totalWeight float64
+	var hues []formats.HSLA
+	for _, c := range profile.Colors {
+		luminance += chromatic.Luminance(c.RGBA) * c.Weight
+		totalWeight += c.Weight
+		if !c.IsNeutral {
+			hues = append(hues, formats.RGBAToHSLA(c.RGBA))
+		}
+	}
+	if totalWeight > 0 {
+		analysis.AverageLuminance = round(luminance / totalWeight)
+	}
+
+	analysis.IsMonochromatic = profile.HasColor &&
+		chromatic.CalculateHueVariance(hues) <= monochromaticHueVariance
+
+	var distance, pairWeight float64
+	for i := 0; i < len(profile.Colors); i++ {
+		for j := i + 1; j < len(profile.Colors); j++ {
+			a, b := profile.Colors[i], profile.Colors[j]
+			w := a.Weight * b.Weight
+			distance += chromatic.DistanceLAB(a.RGBA, b.RGBA) * w
+			pairWeight += w
+		}
+	}
+	if pairWeight > 0 {
+		analysis.PerceptualDiversity = round(math.Min(distance/pairWeight/100, 1))
+	}
+
+	return analysis
+}
+
+// hexa encodes a color as lowercase #rrggbbaa.
+func hexa(c color.RGBA) string {
+	return strings.ToLower(formats.ToHexA(c))
+}
+
+// modeName returns the lowercase metadata name for a theme mode.
+func modeName(mode processor.ThemeMode) string {
+	return strings.ToLower(string(mode))
+}
+
+// parseMode parses a metadata mode name.
+func parseMode(name string) (processor.ThemeMode, error) {
+	mode, err := processor.ParseThemeMode(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errors.ErrInvalidMetadata, err)
+	}
+	return mode, nil
+}
+
+// round rounds to two decimal places for readable metadata.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
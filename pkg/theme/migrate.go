@@ -0,0 +1,127 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+)
+
+// migration upgrades metadata from one schema version to the next.
+type migration struct {
+	to    string
+	apply func(w *Writer, m *Metadata) error
+}
+
+// migrations maps each superseded schema version to its upgrade step.
+// Metadata is migrated step by step until it reaches MetadataVersion.
+var migrations = map[string]migration{
+	"1.0.0": {to: "1.1.0", apply: (*Writer).migrateV1_0},
+	"1.1.0": {to: "1.2.0", apply: (*Writer).migrateV1_1},
+}
+
+// LoadMetadata reads and migrates theme-gen.json from the named theme.
+func (w *Writer) LoadMetadata(name string) (*Metadata, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	dir := w.ThemePath(name)
+	exists, err := pathExists(dir)
+	if err != nil {
+		return nil, &errors.MetadataError{Path: dir, Details: "stat theme", Err: err}
+	}
+	if !exists {
+		return nil, &errors.MetadataError{Path: dir, Details: "load theme", Err: errors.ErrThemeNotFound}
+	}
+
+	path := filepath.Join(dir, MetadataFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &errors.MetadataError{Path: path, Details: "read", Err: err}
+	}
+
+	return w.parseMetadata(path, data)
+}
+
+// ParseMetadata decodes theme-gen.json and migrates it to MetadataVersion.
+func (w *Writer) ParseMetadata(data []byte) (*Metadata, error) {
+	return w.parseMetadata(MetadataFile, data)
+}
+
+// parseMetadata decodes and migrates metadata, reporting failures against path.
+func (w *Writer) parseMetadata(path string, data []byte) (*Metadata, error) {
+	var m Metadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, &errors.MetadataError{Path: path, Details: "decode", Err: fmt.Errorf("%w: %v", errors.ErrInvalidMetadata, err)}
+	}
+
+	if m.Version == "" {
+		return nil, &errors.MetadataError{Path: path, Details: "decode", Err: fmt.Errorf("%w: missing version", errors.ErrInvalidMetadata)}
+	}
+
+	for m.Version != MetadataVersion {
+		from := m.Version
+		step, ok := migrations[from]
+		if !ok {
+			return nil, &errors.MetadataError{Path: path, Version: from, Details: "migrate", Err: errors.ErrUnsupportedMetadataVersion}
+		}
+		if err := step.apply(w, &m); err != nil {
+			return nil, &errors.MetadataError{Path: path, Version: from, Details: "migrate to " + step.to, Err: err}
+		}
+		m.Version = step.to
+	}
+
+	return &m, nil
+}
+
+// migrateV1_0 upgrades the OMARCHY.md 1.0.0 schema, which stores only cluster
+// colors and coverage, by reclassifying each palette color with the current
+// chromatic thresholds. Cluster weights come from coverage percentages, and
+// the detected mode falls back to the generated mode.
+func (w *Writer) migrateV1_0(m *Metadata) error {
+	p := processor.New(w.settings)
+	extracted := &m.ExtractedColors
+
+	extracted.Clusters = make([]Cluster, 0, len(extracted.Palette))
+	for i, hex := range extracted.Palette {
+		rgba, err := formats.ParseHex(hex)
+		if err != nil {
+			return fmt.Errorf("%w: palette color %d: %v", errors.ErrInvalidMetadata, i, err)
+		}
+
+		coverage := extracted.CoverageMap[hex]
+		cluster := p.NewCluster(rgba, coverage.Percentage/100)
+		extracted.Clusters = append(extracted.Clusters, storedCluster(cluster))
+
+		if extracted.SampleCount == 0 && coverage.Percentage > 0 {
+			extracted.SampleCount = int(math.Round(float64(coverage.Pixels) * 100 / coverage.Percentage))
+		}
+	}
+
+	if m.Analysis.DetectedMode == "" {
+		m.Analysis.DetectedMode = m.Generation.Mode
+	}
+
+	return nil
+}
+
+// migrateV1_1 detects the harmony scheme of the stored clusters.
+func (w *Writer) migrateV1_1(m *Metadata) error {
+	profile, err := m.Profile()
+	if err != nil {
+		return err
+	}
+
+	scheme, confidence := palette.New(w.settings).DetectScheme(profile)
+	m.Analysis.DetectedScheme = string(scheme)
+	m.Analysis.SchemeConfidence = round(confidence)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package theme
+
+import (
+	"embed"
+	"image/color"
+	"text/template"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// ComponentFiles lists the component configuration files rendered for every
+// theme, in the order they are written.
+var ComponentFiles = []string{
+	"alacritty.toml",
+	"btop.theme",
+	"hyprland.conf",
+	"hyprlock.conf",
+	"mako.ini",
+	"neovim.lua",
+	"swayosd.css",
+	"walker.css",
+	"waybar.css",
+}
+
+var templates = template.Must(
+	template.New("theme").
+		Funcs(templateFuncs).
+		ParseFS(templateFS, "templates/*.tmpl"),
+)
+
+// templateFuncs maps the per-component color encodings to template functions.
+var templateFuncs = template.FuncMap{
+	"hex": formats.ToHexLower,
+	"rgb": formats.ToHyprRGB,
+	"rgba": func(c color.RGBA, alpha float64) string {
+		return formats.ToDecimalRGBA(formats.WithAlpha(c, alpha))
+	},
+}
+
+// templateData is the value passed to every component template.
+type templateData struct {
+	Name string
+	*palette.SemanticPalette
+}
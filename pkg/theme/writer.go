@@ -0,0 +1,320 @@
+package theme
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+const (
+	// BackgroundsDir is the theme subdirectory containing wallpapers.
+	BackgroundsDir = "backgrounds"
+
+	// LightModeFile marks a theme as preferring light mode when present.
+	LightModeFile = "light.mode"
+
+	dirMode  os.FileMode = 0755
+	fileMode os.FileMode = 0644
+)
+
+type Writer struct {
+	settings *settings.Settings
+}
+
+func New(s *settings.Settings) *Writer {
+	return &Writer{
+		settings: s,
+	}
+}
+
+// ThemesDir returns the configured themes directory with ~ and environment
+// variables expanded.
+func (w *Writer) ThemesDir() string {
+	return expandPath(w.settings.Theme.ThemesDir)
+}
+
+// ThemePath returns the directory a theme with the given name is written to.
+func (w *Writer) ThemePath(name string) string {
+	return filepath.Join(w.ThemesDir(), name)
+}
+
+// Write generates a new theme directory from a semantic palette, the
+// ColorProfile it was built from, and the source image. It fails with
+// ErrThemeExists if a theme with the same name exists. Returns the path of
+// the written theme.
+func (w *Writer) Write(name string, profile *processor.ColorProfile, pal *palette.SemanticPalette, sourceImage string) (string, error) {
+	return w.write(name, w.newMetadata(profile, pal, sourceImage), pal, sourceImage, false)
+}
+
+// Replace generates a theme directory like Write, atomically replacing any
+// existing theme with the same name.
+func (w *Writer) Replace(name string, profile *processor.ColorProfile, pal *palette.SemanticPalette, sourceImage string) (string, error) {
+	return w.write(name, w.newMetadata(profile, pal, sourceImage), pal, sourceImage, true)
+}
+
+// newMetadata captures metadata like NewMetadata and records the harmony
+// scheme detected from the profile with the configured thresholds.
+func (w *Writer) newMetadata(profile *processor.ColorProfile, pal *palette.SemanticPalette, sourceImage string) *Metadata {
+	meta := NewMetadata(profile, pal, sourceImage)
+	scheme, confidence := palette.New(w.settings).DetectScheme(profile)
+	meta.Analysis.DetectedScheme = string(scheme)
+	meta.Analysis.SchemeConfidence = round(confidence)
+	return meta
+}
+
+// Changes describes modifications applied when regenerating a theme from
+// its metadata. Zero fields keep the stored value.
+type Changes struct {
+	Scheme palette.Scheme      // Accent scheme to apply
+	Mode   processor.ThemeMode // Theme mode to apply
+}
+
+// Regenerate rebuilds an existing theme from its theme-gen.json without
+// re-reading the source image, applying any changes. The stored profile is
+// rebuilt in the stored (or changed) mode and scheme, non-null overrides are
+// pinned, and the overrides are carried into the new metadata.
+func (w *Writer) Regenerate(name string, changes Changes) (string, error) {
+	meta, err := w.LoadMetadata(name)
+	if err != nil {
+		return "", err
+	}
+	return w.renderMetadata(name, name, meta, changes, true)
+}
+
+// Clone regenerates the source theme under a new name, applying any changes.
+// It fails with ErrThemeExists if the target theme exists. The source theme
+// is left unchanged.
+func (w *Writer) Clone(source, target string, changes Changes) (string, error) {
+	meta, err := w.LoadMetadata(source)
+	if err != nil {
+		return "", err
+	}
+	return w.renderMetadata(source, target, meta, changes, false)
+}
+
+// renderMetadata builds a palette from the metadata of the source theme and
+// writes it as the target theme, copying the source theme's background.
+func (w *Writer) renderMetadata(source, target string, meta *Metadata, changes Changes, replace bool) (string, error) {
+	path := filepath.Join(w.ThemePath(source), MetadataFile)
+
+	if changes.Mode != "" {
+		meta.Generation.Mode = modeName(changes.Mode)
+	}
+	if changes.Scheme != "" {
+		meta.Generation.Scheme = string(changes.Scheme)
+	}
+
+	profile, err := meta.Profile()
+	if err != nil {
+		return "", &errors.MetadataError{Path: path, Version: meta.Version, Details: "rebuild profile", Err: err}
+	}
+
+	mode, err := meta.Mode()
+	if err != nil {
+		return "", &errors.MetadataError{Path: path, Version: meta.Version, Details: "read mode", Err: err}
+	}
+
+	scheme, err := meta.Scheme()
+	if err != nil {
+		return "", &errors.MetadataError{Path: path, Version: meta.Version, Details: "read scheme", Err: err}
+	}
+
+	overrides, err := meta.Overrides.Palette()
+	if err != nil {
+		return "", &errors.MetadataError{Path: path, Version: meta.Version, Details: "read overrides", Err: err}
+	}
+
+	// Build from a copy so the metadata keeps the detected mode and the
+	// extraction fingerprint is unchanged
+	generated := *profile
+	generated.Mode = mode
+
+	pal, err := palette.New(w.settings).BuildWithOptions(&generated, palette.Options{
+		Scheme:    scheme,
+		Overrides: overrides,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sourceImage := filepath.Join(w.ThemePath(source), filepath.FromSlash(meta.SourceImage))
+
+	updated := NewMetadata(profile, pal, sourceImage)
+	updated.Analysis = meta.Analysis
+	updated.Overrides = meta.Overrides
+
+	return w.write(target, updated, pal, sourceImage, replace)
+}
+
+// write renders every component into a temporary sibling directory and
+// renames it into place, so a failed generation never leaves a partial theme.
+// When replacing, the existing theme is moved aside and restored if the final
+// rename fails.
+func (w *Writer) write(name string, meta *Metadata, pal *palette.SemanticPalette, sourceImage string, replace bool) (string, error) {
+	if err := validateName(name); err != nil {
+		return "", err
+	}
+
+	target := w.ThemePath(name)
+	exists, err := pathExists(target)
+	if err != nil {
+		return "", &errors.ThemeWriteError{Theme: name, Path: target, Operation: "stat", Err: err}
+	}
+	if exists && !replace {
+		return "", &errors.ThemeWriteError{Theme: name, Path: target, Operation: "create", Err: errors.ErrThemeExists}
+	}
+
+	dir := w.ThemesDir()
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return "", &errors.ThemeWriteError{Theme: name, Path: dir, Operation: "create", Err: err}
+	}
+
+	staging, err := os.MkdirTemp(dir, "."+name+"-*")
+	if err != nil {
+		return "", &errors.ThemeWriteError{Theme: name, Path: dir, Operation: "stage", Err: err}
+	}
+	defer os.RemoveAll(staging)
+
+	if err := os.Chmod(staging, dirMode); err != nil {
+		return "", &errors.ThemeWriteError{Theme: name, Path: staging, Operation: "stage", Err: err}
+	}
+
+	if err := w.renderFiles(staging, name, meta, pal, sourceImage); err != nil {
+		return "", err
+	}
+
+	if err := commit(staging, target, exists); err != nil {
+		return "", &errors.ThemeWriteError{Theme: name, Path: target, Operation: "commit", Err: err}
+	}
+
+	return target, nil
+}
+
+// renderFiles writes every theme file into dir.
+func (w *Writer) renderFiles(dir, name string, meta *Metadata, pal *palette.SemanticPalette, sourceImage string) error {
+	data := templateData{Name: name, SemanticPalette: pal}
+
+	for _, file := range ComponentFiles {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, file+".tmpl", data); err != nil {
+			return &errors.ThemeWriteError{Theme: name, Path: file, Operation: "render", Err: err}
+		}
+
+		path := filepath.Join(dir, file)
+		if err := os.WriteFile(path, buf.Bytes(), fileMode); err != nil {
+			return &errors.ThemeWriteError{Theme: name, Path: path, Operation: "write", Err: err}
+		}
+	}
+
+	encoded, err := meta.Marshal()
+	if err != nil {
+		return &errors.ThemeWriteError{Theme: name, Path: MetadataFile, Operation: "encode", Err: err}
+	}
+
+	path := filepath.Join(dir, MetadataFile)
+	if err := os.WriteFile(path, encoded, fileMode); err != nil {
+		return &errors.ThemeWriteError{Theme: name, Path: path, Operation: "write", Err: err}
+	}
+
+	if pal.Mode == processor.Light {
+		path := filepath.Join(dir, LightModeFile)
+		if err := os.WriteFile(path, nil, fileMode); err != nil {
+			return &errors.ThemeWriteError{Theme: name, Path: path, Operation: "write", Err: err}
+		}
+	}
+
+	backgrounds := filepath.Join(dir, BackgroundsDir)
+	if err := os.Mkdir(backgrounds, dirMode); err != nil {
+		return &errors.ThemeWriteError{Theme: name, Path: backgrounds, Operation: "create", Err: err}
+	}
+
+	dest := filepath.Join(backgrounds, filepath.Base(sourceImage))
+	if err := copyFile(sourceImage, dest); err != nil {
+		return &errors.ThemeWriteError{Theme: name, Path: sourceImage, Operation: "copy", Err: err}
+	}
+
+	return nil
+}
+
+// commit moves the staged theme into place. An existing target is renamed
+// aside first and restored if the staged directory cannot be moved in.
+func commit(staging, target string, exists bool) error {
+	if !exists {
+		return os.Rename(staging, target)
+	}
+
+	backup := staging + ".old"
+	if err := os.Rename(target, backup); err != nil {
+		return err
+	}
+
+	if err := os.Rename(staging, target); err != nil {
+		if restoreErr := os.Rename(backup, target); restoreErr != nil {
+			return restoreErr
+		}
+		return err
+	}
+
+	return os.RemoveAll(backup)
+}
+
+// copyFile copies src to dst with the theme file mode.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// validateName ensures the theme name is a single, non-hidden path element.
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return &errors.ThemeWriteError{Theme: name, Path: name, Operation: "validate name", Err: errors.ErrInvalidThemeName}
+	}
+	return nil
+}
+
+// pathExists reports whether path exists.
+func pathExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// expandPath expands a leading ~ to the user's home directory and any
+// environment variables in path.
+func expandPath(path string) string {
+	path = os.ExpandEnv(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
@@ -0,0 +1,145 @@
+package benchmarks_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+const (
+	width4K  = 3840
+	height4K = 2160
+)
+
+// opaqueImage hides the concrete image type so processing falls back to
+// generic per-pixel color conversion.
+type opaqueImage struct {
+	image.Image
+}
+
+// create4KImage builds a 4K image of four dominant regions with per-pixel
+// texture so the histogram resembles a photograph rather than flat fills.
+func create4KImage() *image.RGBA {
+	regions := []color.RGBA{
+		{R: 30, G: 40, B: 70, A: 255},
+		{R: 200, G: 120, B: 60, A: 255},
+		{R: 60, G: 140, B: 90, A: 255},
+		{R: 220, G: 210, B: 190, A: 255},
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width4K, height4K))
+	for y := 0; y < height4K; y++ {
+		for x := 0; x < width4K; x++ {
+			base := regions[(y*2/height4K)*2+x*2/width4K]
+			noise := uint8((x*7 + y*13) % 24)
+			img.Set(x, y, color.RGBA{
+				R: base.R + noise,
+				G: base.G + noise/2,
+				B: base.B + noise/3,
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	b := src.Bounds()
+	img := image.NewNRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			img.Set(x, y, src.At(x, y))
+		}
+	}
+	return img
+}
+
+func encode4KJPEG(b *testing.B) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, create4KImage(), &jpeg.Options{Quality: 90}); err != nil {
+		b.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decodeJPEG(b *testing.B, data []byte) image.Image {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		b.Fatalf("Failed to decode JPEG: %v", err)
+	}
+	return img
+}
+
+func benchmarkProcess(b *testing.B, img image.Image) {
+	p := processor.New(settings.DefaultSettings())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := p.ProcessImage(img); err != nil {
+			b.Fatalf("Processing failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkProcess4K_RGBA benchmarks processing a 4K *image.RGBA through the
+// direct Pix fast path
+func BenchmarkProcess4K_RGBA(b *testing.B) {
+	benchmarkProcess(b, create4KImage())
+}
+
+// BenchmarkProcess4K_RGBA_Generic benchmarks the same image through generic
+// color conversion for comparison
+func BenchmarkProcess4K_RGBA_Generic(b *testing.B) {
+	benchmarkProcess(b, opaqueImage{create4KImage()})
+}
+
+// BenchmarkProcess4K_NRGBA benchmarks processing a 4K *image.NRGBA, as
+// produced by the PNG decoder
+func BenchmarkProcess4K_NRGBA(b *testing.B) {
+	benchmarkProcess(b, toNRGBA(create4KImage()))
+}
+
+// BenchmarkProcess4K_NRGBA_Generic benchmarks the same image through generic
+// color conversion for comparison
+func BenchmarkProcess4K_NRGBA_Generic(b *testing.B) {
+	benchmarkProcess(b, opaqueImage{toNRGBA(create4KImage())})
+}
+
+// BenchmarkProcess4K_YCbCr benchmarks processing a decoded 4K JPEG, which
+// converts YCbCr inline
+func BenchmarkProcess4K_YCbCr(b *testing.B) {
+	img := decodeJPEG(b, encode4KJPEG(b))
+	if _, ok := img.(*image.YCbCr); !ok {
+		b.Fatalf("Expected *image.YCbCr from JPEG decoder, got %T", img)
+	}
+	benchmarkProcess(b, img)
+}
+
+// BenchmarkProcess4K_YCbCr_Generic benchmarks the same decoded JPEG through
+// generic color conversion for comparison
+func BenchmarkProcess4K_YCbCr_Generic(b *testing.B) {
+	benchmarkProcess(b, opaqueImage{decodeJPEG(b, encode4KJPEG(b))})
+}
+
+// BenchmarkDecodeToProfile4K benchmarks the full path from encoded 4K JPEG
+// bytes to a color profile
+func BenchmarkDecodeToProfile4K(b *testing.B) {
+	data := encode4KJPEG(b)
+	p := processor.New(settings.DefaultSettings())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		img := decodeJPEG(b, data)
+		if _, err := p.ProcessImage(img); err != nil {
+			b.Fatalf("Processing failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package chromatic_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// TestAPCAContrast tests Lc values against the APCA 0.0.98G-4g reference
+// implementation
+func TestAPCAContrast(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		bg       string
+		expected float64
+	}{
+		{"Gray on white", "#888888", "#FFFFFF", 63.056469930209424},
+		{"White on gray", "#FFFFFF", "#888888", -68.54146436644962},
+		{"Black on light gray", "#000000", "#AAAAAA", 58.146262578561334},
+		{"Light gray on black", "#AAAAAA", "#000000", -56.24113336839742},
+		{"Identical colors", "#336699", "#336699", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			text, _ := formats.ParseHex(tc.text)
+			bg, _ := formats.ParseHex(tc.bg)
+
+			lc := chromatic.APCAContrast(text, bg)
+			t.Logf("%s on %s: Lc %.4f (expected %.4f)", tc.text, tc.bg, lc, tc.expected)
+
+			if math.Abs(lc-tc.expected) > 0.001 {
+				t.Errorf("Expected Lc %.4f, got %.4f", tc.expected, lc)
+			}
+		})
+	}
+}
+
+// TestAPCAContrast_Polarity tests that APCA distinguishes polarity where the
+// WCAG 2 ratio cannot
+func TestAPCAContrast_Polarity(t *testing.T) {
+	dark := color.RGBA{R: 136, G: 136, B: 136, A: 255}
+	light := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	normal := chromatic.APCAContrast(dark, light)
+	reverse := chromatic.APCAContrast(light, dark)
+	t.Logf("Dark on light: Lc %.2f, light on dark: Lc %.2f, WCAG %.2f:1",
+		normal, reverse, chromatic.ContrastRatio(dark, light))
+
+	if normal <= 0 {
+		t.Errorf("Expected positive Lc for dark text on light background, got %.2f", normal)
+	}
+	if reverse >= 0 {
+		t.Errorf("Expected negative Lc for light text on dark background, got %.2f", reverse)
+	}
+	if math.Abs(normal-math.Abs(reverse)) < 1 {
+		t.Errorf("Expected polarity to change the Lc magnitude")
+	}
+}
+
+// TestAPCAContrast_DarkTheme tests a pair WCAG 2 passes but APCA rates as
+// too weak for body text, the failure mode WCAG 2 has on dark themes
+func TestAPCAContrast_DarkTheme(t *testing.T) {
+	text := color.RGBA{R: 118, G: 118, B: 118, A: 255}
+	bg := color.RGBA{R: 0, G: 0, B: 0, A: 255}
+
+	ratio := chromatic.ContrastRatio(text, bg)
+	lc := chromatic.APCAContrast(text, bg)
+	t.Logf("#767676 on black: WCAG %.2f:1, APCA Lc %.2f", ratio, lc)
+
+	if !chromatic.IsAccessible(text, bg, chromatic.AA) {
+		t.Errorf("Expected pair to pass WCAG AA (%.2f:1)", ratio)
+	}
+	if chromatic.MeetsAPCA(text, bg, chromatic.APCABody) {
+		t.Errorf("Expected pair to fail APCA body text (Lc %.2f)", lc)
+	}
+}
+
+func TestAPCALevel_Lc(t *testing.T) {
+	testCases := []struct {
+		level    chromatic.APCALevel
+		expected float64
+	}{
+		{chromatic.APCAFluent, 90},
+		{chromatic.APCABody, 75},
+		{chromatic.APCAContent, 60},
+		{chromatic.APCALarge, 45},
+		{chromatic.APCASpot, 30},
+		{chromatic.APCANonText, 15},
+		{chromatic.APCALevel("unknown"), 75},
+	}
+
+	for _, tc := range testCases {
+		if lc := tc.level.Lc(); lc != tc.expected {
+			t.Errorf("%s: expected Lc %.0f, got %.0f", tc.level, tc.expected, lc)
+		}
+	}
+}
@@ -348,4 +348,82 @@ func TestChromaColorsSimilar_EdgeCases(t *testing.T) {
 			}
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestChroma_DistanceMetric(t *testing.T) {
+	c1 := color.RGBA{R: 20, G: 40, B: 220, A: 255}
+	c2 := color.RGBA{R: 60, G: 30, B: 230, A: 255}
+
+	testCases := []struct {
+		metric    string
+		expected  float64
+		threshold func(s *settings.Settings) *float64
+	}{
+		{"cie76", chromatic.DistanceLAB(c1, c2), func(s *settings.Settings) *float64 { return &s.Chromatic.ColorMergeThreshold }},
+		{"cie94", chromatic.DistanceCIE94(c1, c2), func(s *settings.Settings) *float64 { return &s.Chromatic.CIE94MergeThreshold }},
+		{"ciede2000", chromatic.DistanceCIEDE2000(c1, c2), func(s *settings.Settings) *float64 { return &s.Chromatic.CIEDE2000MergeThreshold }},
+		{"unknown", chromatic.DistanceLAB(c1, c2), func(s *settings.Settings) *float64 { return &s.Chromatic.ColorMergeThreshold }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.metric, func(t *testing.T) {
+			s := settings.DefaultSettings()
+			s.Chromatic.DistanceMetric = tc.metric
+			chroma := chromatic.NewChroma(s)
+
+			d := chroma.Distance(c1, c2)
+			t.Logf("%s distance: %.3f", tc.metric, d)
+			if d != tc.expected {
+				t.Errorf("Expected %.3f, got %.3f", tc.expected, d)
+			}
+
+			// ColorsSimilar compares the configured metric to its own merge threshold
+			threshold := tc.threshold(s)
+			if chroma.MergeThreshold() != *threshold {
+				t.Errorf("Expected merge threshold %.2f, got %.2f", *threshold, chroma.MergeThreshold())
+			}
+			*threshold = d + 0.01
+			if !chroma.ColorsSimilar(c1, c2) {
+				t.Errorf("Expected colors similar just under threshold")
+			}
+			*threshold = d - 0.01
+			if chroma.ColorsSimilar(c1, c2) {
+				t.Errorf("Expected colors dissimilar just over threshold")
+			}
+		})
+	}
+}
+
+func TestChroma_MergeThresholdDefaults(t *testing.T) {
+	// Default thresholds agree across metrics on clearly close and clearly
+	// distinct chromatic pairs
+	testCases := []struct {
+		name    string
+		c1, c2  color.RGBA
+		similar bool
+	}{
+		{"Close reds", color.RGBA{R: 200, G: 60, B: 50, A: 255}, color.RGBA{R: 210, G: 70, B: 55, A: 255}, true},
+		{"Close blues", color.RGBA{R: 40, G: 70, B: 200, A: 255}, color.RGBA{R: 50, G: 75, B: 215, A: 255}, true},
+		{"Red and orange", color.RGBA{R: 200, G: 60, B: 50, A: 255}, color.RGBA{R: 230, G: 130, B: 40, A: 255}, false},
+		{"Blue and violet", color.RGBA{R: 40, G: 70, B: 200, A: 255}, color.RGBA{R: 120, G: 60, B: 210, A: 255}, false},
+		{"Teal and green", color.RGBA{R: 30, G: 150, B: 150, A: 255}, color.RGBA{R: 60, G: 170, B: 70, A: 255}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, metric := range []chromatic.DistanceMetric{chromatic.CIE76, chromatic.CIE94, chromatic.CIEDE2000} {
+				s := settings.DefaultSettings()
+				s.Chromatic.DistanceMetric = string(metric)
+				chroma := chromatic.NewChroma(s)
+
+				d := chroma.Distance(tc.c1, tc.c2)
+				similar := chroma.ColorsSimilar(tc.c1, tc.c2)
+				t.Logf("%s: distance %.2f, threshold %.1f, similar %t", metric, d, chroma.MergeThreshold(), similar)
+
+				if similar != tc.similar {
+					t.Errorf("%s: expected similar=%t at distance %.2f", metric, tc.similar, d)
+				}
+			}
+		})
+	}
+}
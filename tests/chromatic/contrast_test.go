@@ -3,6 +3,7 @@ package chromatic_test
 import (
 	"image/color"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
@@ -219,6 +220,8 @@ func TestAccessibilityLevel_Ratio(t *testing.T) {
 		{chromatic.AAA, 7.0},
 		{chromatic.AALarge, 3.0},
 		{chromatic.AAALarge, 4.5},
+		{chromatic.NonText, 3.0},
+		{chromatic.AccessibilityLevel("unknown"), 4.5},
 	}
 
 	for _, tc := range testCases {
@@ -300,4 +303,102 @@ func TestContrastRatioEdgeCases(t *testing.T) {
 			t.Errorf("Black/white should have exactly 21:1 contrast, got %v", ratio)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestMeetsLevels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		c1       color.RGBA
+		c2       color.RGBA
+		expected []chromatic.AccessibilityLevel
+	}{
+		{
+			name:     "Black and White",
+			c1:       color.RGBA{R: 0, G: 0, B: 0, A: 255},
+			c2:       color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			expected: chromatic.AccessibilityLevels,
+		},
+		{
+			name:     "Mid Gray on White",
+			c1:       color.RGBA{R: 118, G: 118, B: 118, A: 255}, // ~4.54:1
+			c2:       color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			expected: []chromatic.AccessibilityLevel{chromatic.AA, chromatic.AAALarge, chromatic.AALarge, chromatic.NonText},
+		},
+		{
+			name:     "Light Gray on White",
+			c1:       color.RGBA{R: 148, G: 148, B: 148, A: 255}, // ~3.03:1
+			c2:       color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			expected: []chromatic.AccessibilityLevel{chromatic.AALarge, chromatic.NonText},
+		},
+		{
+			name:     "Identical Colors",
+			c1:       color.RGBA{R: 128, G: 128, B: 128, A: 255},
+			c2:       color.RGBA{R: 128, G: 128, B: 128, A: 255},
+			expected: []chromatic.AccessibilityLevel{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			levels := chromatic.MeetsLevels(tc.c1, tc.c2)
+			t.Logf("Ratio %.3f:1 meets %v", chromatic.ContrastRatio(tc.c1, tc.c2), levels)
+
+			if len(levels) != len(tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, levels)
+			}
+			for i := range levels {
+				if levels[i] != tc.expected[i] {
+					t.Errorf("Expected %v, got %v", tc.expected, levels)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestParseAccessibilityLevel(t *testing.T) {
+	for _, level := range chromatic.AccessibilityLevels {
+		parsed, err := chromatic.ParseAccessibilityLevel(string(level))
+		if err != nil {
+			t.Errorf("Expected %s to parse: %v", level, err)
+		}
+		if parsed != level {
+			t.Errorf("Expected %s, got %s", level, parsed)
+		}
+	}
+
+	normalized := []struct {
+		name     string
+		expected chromatic.AccessibilityLevel
+	}{
+		{"aa", chromatic.AA},
+		{" AAA ", chromatic.AAA},
+		{"aaa-LARGE", chromatic.AAALarge},
+		{"Non-Text", chromatic.NonText},
+	}
+	for _, tc := range normalized {
+		parsed, err := chromatic.ParseAccessibilityLevel(tc.name)
+		if err != nil {
+			t.Errorf("Expected %q to parse: %v", tc.name, err)
+			continue
+		}
+		t.Logf("Level %q parsed as %s", tc.name, parsed)
+		if parsed != tc.expected {
+			t.Errorf("Expected %q to parse as %s, got %s", tc.name, tc.expected, parsed)
+		}
+	}
+
+	for _, name := range []string{"", "A", "AAA-lrg", "nontext", "unknown"} {
+		t.Run("invalid "+name, func(t *testing.T) {
+			_, err := chromatic.ParseAccessibilityLevel(name)
+			if err == nil {
+				t.Fatalf("Expected an error for level %q", name)
+			}
+			t.Logf("Level %q: %v", name, err)
+
+			if !strings.Contains(err.Error(), "AAA, AA, AAA-large, AA-large, non-text") {
+				t.Errorf("Expected error to list the valid levels: %v", err)
+			}
+		})
+	}
+}
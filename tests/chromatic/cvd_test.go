@@ -0,0 +1,117 @@
+package chromatic_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// TestSimulateDeficiency tests primaries against the Machado 2009 full
+// severity matrices
+func TestSimulateDeficiency(t *testing.T) {
+	red := color.RGBA{R: 255, G: 0, B: 0, A: 255}
+	green := color.RGBA{R: 0, G: 255, B: 0, A: 255}
+	blue := color.RGBA{R: 0, G: 0, B: 255, A: 255}
+
+	testCases := []struct {
+		name       string
+		input      color.RGBA
+		deficiency chromatic.Deficiency
+		expected   color.RGBA
+	}{
+		{"Red protanopia", red, chromatic.Protanopia, color.RGBA{R: 109, G: 95, B: 0, A: 255}},
+		{"Red deuteranopia", red, chromatic.Deuteranopia, color.RGBA{R: 163, G: 144, B: 0, A: 255}},
+		{"Green deuteranopia", green, chromatic.Deuteranopia, color.RGBA{R: 239, G: 214, B: 58, A: 255}},
+		{"Blue tritanopia", blue, chromatic.Tritanopia, color.RGBA{R: 0, G: 107, B: 150, A: 255}},
+		{"Unknown deficiency", red, chromatic.Deficiency("unknown"), red},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := chromatic.SimulateDeficiency(tc.input, tc.deficiency)
+			t.Logf("%s with %s: %s (expected %s)", formats.ToHex(tc.input), tc.deficiency,
+				formats.ToHex(result), formats.ToHex(tc.expected))
+
+			if !colorsClose(result, tc.expected, 1) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestSimulateDeficiency_PreservesNeutrals tests that grays and alpha are
+// unchanged, since every deficiency preserves achromatic lightness
+func TestSimulateDeficiency_PreservesNeutrals(t *testing.T) {
+	for _, d := range chromatic.Deficiencies {
+		for _, v := range []uint8{0, 64, 128, 200, 255} {
+			gray := color.RGBA{R: v, G: v, B: v, A: 128}
+			result := chromatic.SimulateDeficiency(gray, d)
+			if !colorsClose(result, gray, 1) || result.A != gray.A {
+				t.Errorf("%s changed gray %v to %v", d, gray, result)
+			}
+		}
+	}
+}
+
+// TestCheckDeficiencies tests that red and green collapse for red-green
+// deficiencies while blue and yellow stay distinct
+func TestCheckDeficiencies(t *testing.T) {
+	s := settings.DefaultSettings()
+	chroma := chromatic.NewChroma(s)
+
+	colors := []chromatic.NamedColor{
+		{Name: "red", Color: color.RGBA{R: 200, G: 60, B: 50, A: 255}},
+		{Name: "green", Color: color.RGBA{R: 90, G: 160, B: 50, A: 255}},
+		{Name: "blue", Color: color.RGBA{R: 40, G: 90, B: 220, A: 255}},
+		{Name: "yellow", Color: color.RGBA{R: 235, G: 210, B: 60, A: 255}},
+	}
+
+	report := chroma.CheckDeficiencies(colors)
+	for _, c := range report.Conflicts {
+		t.Logf("%s: %s/%s %.1f -> %.1f", c.Deficiency, c.First.Name, c.Second.Name, c.Original, c.Simulated)
+	}
+
+	if report.Safe() {
+		t.Fatal("Expected conflicts for a red and green pair")
+	}
+
+	hasPair := func(conflicts []chromatic.DeficiencyConflict, a, b string) bool {
+		for _, c := range conflicts {
+			if (c.First.Name == a && c.Second.Name == b) || (c.First.Name == b && c.Second.Name == a) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasPair(report.For(chromatic.Deuteranopia), "red", "green") {
+		t.Error("Expected red and green to collapse under deuteranopia")
+	}
+	for _, d := range chromatic.Deficiencies {
+		if hasPair(report.For(d), "blue", "yellow") {
+			t.Errorf("Expected blue and yellow to stay distinct under %s", d)
+		}
+	}
+
+	for _, c := range report.Conflicts {
+		if c.Simulated >= s.Chromatic.DeficiencyDistanceThreshold {
+			t.Errorf("%s/%s reported at %.1f, above threshold", c.First.Name, c.Second.Name, c.Simulated)
+		}
+		if c.Original < s.Chromatic.DeficiencyDistanceThreshold {
+			t.Errorf("%s/%s reported though already similar at %.1f", c.First.Name, c.Second.Name, c.Original)
+		}
+	}
+}
+
+func colorsClose(a, b color.RGBA, tolerance int) bool {
+	diff := func(x, y uint8) int {
+		if x > y {
+			return int(x - y)
+		}
+		return int(y - x)
+	}
+	return diff(a.R, b.R) <= tolerance && diff(a.G, b.G) <= tolerance && diff(a.B, b.B) <= tolerance
+}
@@ -342,4 +342,81 @@ func TestDistanceTriangleInequality(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// TestDeltaE2000 tests CIEDE2000 against the reference data published by
+// Sharma, Wu, and Dalal (2005)
+func TestDeltaE2000(t *testing.T) {
+	testCases := []struct {
+		lab1, lab2 formats.LAB
+		expected   float64
+	}{
+		{formats.NewLAB(50, 2.6772, -79.7751), formats.NewLAB(50, 0, -82.7485), 2.0425},
+		{formats.NewLAB(50, 3.1571, -77.2803), formats.NewLAB(50, 0, -82.7485), 2.8615},
+		{formats.NewLAB(50, 0, 0), formats.NewLAB(50, -1, 2), 2.3669},
+		{formats.NewLAB(50, 2.49, -0.001), formats.NewLAB(50, -2.49, 0.0009), 7.1792},
+		{formats.NewLAB(50, 2.5, 0), formats.NewLAB(73, 25, -18), 27.1492},
+		{formats.NewLAB(50, 2.5, 0), formats.NewLAB(50, 3.1736, 0.5854), 1.0000},
+		{formats.NewLAB(60.2574, -34.0099, 36.2677), formats.NewLAB(60.4626, -34.1751, 39.4387), 1.2644},
+		{formats.NewLAB(63.0109, -31.0961, -5.8663), formats.NewLAB(62.8187, -29.7946, -4.0864), 1.2630},
+		{formats.NewLAB(22.7233, 20.0904, -46.694), formats.NewLAB(23.0331, 14.973, -42.5619), 2.0373},
+		{formats.NewLAB(2.0776, 0.0795, -1.135), formats.NewLAB(0.9033, -0.0636, -0.5514), 0.9082},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Pair %d", i+1), func(t *testing.T) {
+			forward := chromatic.DeltaE2000(tc.lab1, tc.lab2)
+			reverse := chromatic.DeltaE2000(tc.lab2, tc.lab1)
+
+			t.Logf("%s vs %s: ΔE00=%.4f (expected %.4f)", tc.lab1, tc.lab2, forward, tc.expected)
+
+			if math.Abs(forward-tc.expected) > 0.0001 {
+				t.Errorf("Expected %.4f, got %.4f", tc.expected, forward)
+			}
+			if math.Abs(forward-reverse) > 1e-9 {
+				t.Errorf("CIEDE2000 should be symmetric: %.6f vs %.6f", forward, reverse)
+			}
+		})
+	}
+}
+
+func TestDeltaE94(t *testing.T) {
+	lab1 := formats.NewLAB(50, 2.6772, -79.7751)
+	lab2 := formats.NewLAB(50, 0, -82.7485)
+
+	d := chromatic.DeltaE94(lab1, lab2)
+	t.Logf("ΔE94 = %.4f", d)
+	if math.Abs(d-1.3950) > 0.001 {
+		t.Errorf("Expected ΔE94 1.3950, got %.4f", d)
+	}
+
+	// Pure lightness differences are unweighted
+	if d := chromatic.DeltaE94(formats.NewLAB(40, 20, 20), formats.NewLAB(50, 20, 20)); math.Abs(d-10) > 1e-9 {
+		t.Errorf("Expected ΔE94 10 for lightness-only difference, got %.4f", d)
+	}
+}
+
+// TestDistanceMetrics_Saturated tests that CIE94 and CIEDE2000 compress
+// differences between saturated colors relative to CIE76
+func TestDistanceMetrics_Saturated(t *testing.T) {
+	pairs := []struct {
+		name   string
+		c1, c2 color.RGBA
+	}{
+		{"Saturated blues", color.RGBA{R: 20, G: 40, B: 220, A: 255}, color.RGBA{R: 60, G: 30, B: 230, A: 255}},
+		{"Saturated yellows", color.RGBA{R: 250, G: 220, B: 20, A: 255}, color.RGBA{R: 240, G: 200, B: 0, A: 255}},
+	}
+
+	for _, p := range pairs {
+		t.Run(p.name, func(t *testing.T) {
+			d76 := chromatic.DistanceLAB(p.c1, p.c2)
+			d94 := chromatic.DistanceCIE94(p.c1, p.c2)
+			d00 := chromatic.DistanceCIEDE2000(p.c1, p.c2)
+			t.Logf("CIE76=%.2f CIE94=%.2f CIEDE2000=%.2f", d76, d94, d00)
+
+			if d94 >= d76 || d00 >= d76 {
+				t.Errorf("Expected weighted metrics below CIE76 for saturated pair")
+			}
+		})
+	}
+}
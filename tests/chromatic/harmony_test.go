@@ -0,0 +1,192 @@
+package chromatic_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+func TestChroma_Harmony(t *testing.T) {
+	s := settings.DefaultSettings()
+	chroma := chromatic.NewChroma(s)
+	base := formats.NewHSL(210, 0.7, 0.55)
+
+	testCases := []struct {
+		harmony chromatic.Harmony
+		hues    []float64
+	}{
+		{chromatic.HarmonyComplementary, []float64{210, 30}},
+		{chromatic.HarmonySplitComplementary, []float64{210, 0, 60}},
+		{chromatic.HarmonyAnalogous, []float64{210, 240, 180}},
+		{chromatic.HarmonyTriadic, []float64{210, 330, 90}},
+		{chromatic.HarmonyTetradic, []float64{210, 300, 30, 120}},
+		{chromatic.HarmonyMonochromatic, []float64{210, 210, 210}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.harmony), func(t *testing.T) {
+			colors, err := chroma.Harmony(tc.harmony, base)
+			if err != nil {
+				t.Fatalf("Harmony failed: %v", err)
+			}
+
+			for i, c := range colors {
+				t.Logf("  %d: H=%.1f° S=%.2f L=%.2f", i, c.H, c.S, c.L)
+			}
+
+			if len(colors) != len(tc.hues) {
+				t.Fatalf("Expected %d colors, got %d", len(tc.hues), len(colors))
+			}
+			if colors[0] != base {
+				t.Errorf("Expected base as first color, got %+v", colors[0])
+			}
+
+			for i, expected := range tc.hues {
+				if math.Abs(colors[i].H-expected) > 0.001 {
+					t.Errorf("Color %d: expected hue %.1f°, got %.1f°", i, expected, colors[i].H)
+				}
+				if colors[i].S != base.S || colors[i].A != base.A {
+					t.Errorf("Color %d: expected saturation and alpha preserved, got %+v", i, colors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChroma_HarmonySettings(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Chromatic.AnalogousSpread = 45
+	s.Chromatic.SplitComplementaryAngle = 15
+	chroma := chromatic.NewChroma(s)
+	base := formats.NewHSL(350, 0.6, 0.5)
+
+	analogous := chroma.Analogous(base)
+	t.Logf("Analogous (45°): %.1f° %.1f° %.1f°", analogous[0].H, analogous[1].H, analogous[2].H)
+	if analogous[1].H != 35 || analogous[2].H != 305 {
+		t.Errorf("Expected analogous hues 35° and 305°, got %.1f° and %.1f°", analogous[1].H, analogous[2].H)
+	}
+
+	split := chroma.SplitComplementary(base)
+	t.Logf("Split-complementary (15°): %.1f° %.1f° %.1f°", split[0].H, split[1].H, split[2].H)
+	if split[1].H != 155 || split[2].H != 185 {
+		t.Errorf("Expected split hues 155° and 185°, got %.1f° and %.1f°", split[1].H, split[2].H)
+	}
+}
+
+func TestChroma_Monochromatic(t *testing.T) {
+	s := settings.DefaultSettings()
+	chroma := chromatic.NewChroma(s)
+
+	testCases := []struct {
+		name      string
+		lightness float64
+		expected  []float64
+	}{
+		{"Mid lightness", 0.5, []float64{0.5, 0.65, 0.35}},
+		{"Near top widens darker steps", 0.8, []float64{0.8, 0.65, 0.5}},
+		{"Near bottom widens lighter steps", 0.25, []float64{0.25, 0.4, 0.55}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			colors := chroma.Monochromatic(formats.NewHSL(120, 0.5, tc.lightness))
+			for i, expected := range tc.expected {
+				t.Logf("  %d: L=%.3f (expected %.3f)", i, colors[i].L, expected)
+				if math.Abs(colors[i].L-expected) > 0.0001 {
+					t.Errorf("Variant %d: expected lightness %.3f, got %.3f", i, expected, colors[i].L)
+				}
+				if colors[i].H != 120 {
+					t.Errorf("Variant %d: expected hue 120°, got %.1f°", i, colors[i].H)
+				}
+			}
+		})
+	}
+}
+
+func TestParseHarmony(t *testing.T) {
+	for _, h := range chromatic.Harmonies {
+		parsed, err := chromatic.ParseHarmony(string(h))
+		if err != nil || parsed != h {
+			t.Errorf("ParseHarmony(%q) = %q, %v", h, parsed, err)
+		}
+	}
+
+	if _, err := chromatic.ParseHarmony("pentadic"); err == nil {
+		t.Error("Expected error for unknown harmony")
+	}
+}
+
+func TestChroma_DetectHarmony(t *testing.T) {
+	s := settings.DefaultSettings()
+	chroma := chromatic.NewChroma(s)
+
+	hues := func(values ...float64) []chromatic.WeightedHue {
+		result := make([]chromatic.WeightedHue, len(values))
+		for i, h := range values {
+			result[i] = chromatic.WeightedHue{Hue: h, Weight: 1}
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		hues     []chromatic.WeightedHue
+		expected chromatic.Harmony
+	}{
+		{"Single hue", hues(210), chromatic.HarmonyMonochromatic},
+		{"Close hues", hues(205, 212, 218), chromatic.HarmonyMonochromatic},
+		{"Opposite hues", hues(210, 30), chromatic.HarmonyComplementary},
+		{"Neighboring hues", hues(200, 230, 260), chromatic.HarmonyAnalogous},
+		{"Split complement", hues(0, 150, 210), chromatic.HarmonySplitComplementary},
+		{"Triad", hues(0, 120, 240), chromatic.HarmonyTriadic},
+		{"Square", hues(10, 100, 190, 280), chromatic.HarmonyTetradic},
+		{"Triad across 0°", hues(350, 110, 230), chromatic.HarmonyTriadic},
+		{
+			"Minor off-harmony hue",
+			[]chromatic.WeightedHue{{Hue: 210, Weight: 0.5}, {Hue: 30, Weight: 0.45}, {Hue: 120, Weight: 0.05}},
+			chromatic.HarmonyComplementary,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match := chroma.DetectHarmony(tc.hues)
+			t.Logf("Detected %s at %.1f° (confidence %.2f)", match.Harmony, match.Base, match.Confidence)
+
+			if match.Harmony != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, match.Harmony)
+			}
+			if match.Confidence <= 0 || match.Confidence > 1 {
+				t.Errorf("Confidence %.3f out of range", match.Confidence)
+			}
+		})
+	}
+
+	empty := chroma.DetectHarmony(nil)
+	if empty.Harmony != "" || empty.Confidence != 0 {
+		t.Errorf("Expected empty match for no hues, got %+v", empty)
+	}
+}
+
+func TestChroma_DetectHarmony_ZeroTolerance(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Chromatic.HarmonyHueTolerance = 0
+	chroma := chromatic.NewChroma(s)
+
+	exact := chroma.DetectHarmony([]chromatic.WeightedHue{{Hue: 210, Weight: 1}, {Hue: 30, Weight: 1}})
+	t.Logf("Exact hues: %s at %.1f° (confidence %.2f)", exact.Harmony, exact.Base, exact.Confidence)
+
+	if exact.Harmony != chromatic.HarmonyComplementary || math.Abs(exact.Confidence-1) > 1e-9 {
+		t.Errorf("Expected an exact complementary match with confidence 1, got %+v", exact)
+	}
+
+	near := chroma.DetectHarmony([]chromatic.WeightedHue{{Hue: 210, Weight: 1}, {Hue: 35, Weight: 1}})
+	t.Logf("Near hues: %s at %.1f° (confidence %.2f)", near.Harmony, near.Base, near.Confidence)
+
+	if math.IsNaN(near.Confidence) || near.Confidence < 0 || near.Confidence > 1 {
+		t.Errorf("Confidence %.3f out of range", near.Confidence)
+	}
+}
@@ -0,0 +1,197 @@
+package chromatic_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+func TestEnforceContrast(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fg          color.RGBA
+		bg          color.RGBA
+		level       chromatic.AccessibilityLevel
+		expectMoved bool
+		expectPass  bool
+		expectLight bool // Expected direction of movement when moved
+	}{
+		{
+			name:        "Already accessible",
+			fg:          color.RGBA{R: 230, G: 230, B: 230, A: 255},
+			bg:          color.RGBA{R: 20, G: 20, B: 20, A: 255},
+			level:       chromatic.AA,
+			expectMoved: false,
+			expectPass:  true,
+		},
+		{
+			name:        "Dim text on dark background",
+			fg:          color.RGBA{R: 90, G: 100, B: 140, A: 255},
+			bg:          color.RGBA{R: 30, G: 32, B: 48, A: 255},
+			level:       chromatic.AA,
+			expectMoved: true,
+			expectPass:  true,
+			expectLight: true,
+		},
+		{
+			name:        "Pale accent on light background",
+			fg:          color.RGBA{R: 240, G: 180, B: 120, A: 255},
+			bg:          color.RGBA{R: 245, G: 243, B: 238, A: 255},
+			level:       chromatic.AALarge,
+			expectMoved: true,
+			expectPass:  true,
+			expectLight: false,
+		},
+		{
+			name:        "Unreachable AAA on mid gray",
+			fg:          color.RGBA{R: 120, G: 120, B: 120, A: 255},
+			bg:          color.RGBA{R: 119, G: 119, B: 119, A: 255},
+			level:       chromatic.AAA,
+			expectMoved: true,
+			expectPass:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := chromatic.EnforceContrast(tc.fg, tc.bg, tc.level)
+
+			t.Logf("Foreground: %s -> %s", formats.ToHex(result.Original), formats.ToHex(result.Adjusted))
+			t.Logf("Background: %s", formats.ToHex(result.Background))
+			t.Logf("Ratio: %.2f -> %.2f (target %.1f for %s)", result.OriginalRatio, result.AdjustedRatio, tc.level.Ratio(), tc.level)
+			t.Logf("Lightness delta: %.2f, Satisfied: %t", result.LightnessDelta, result.Satisfied)
+
+			if result.Moved() != tc.expectMoved {
+				t.Errorf("Expected moved=%t, got %t", tc.expectMoved, result.Moved())
+			}
+			if result.Satisfied != tc.expectPass {
+				t.Errorf("Expected satisfied=%t, got %t", tc.expectPass, result.Satisfied)
+			}
+			if result.Satisfied && result.AdjustedRatio < tc.level.Ratio() {
+				t.Errorf("Satisfied reported but ratio %.2f < %.1f", result.AdjustedRatio, tc.level.Ratio())
+			}
+
+			if !tc.expectMoved || !tc.expectPass {
+				return
+			}
+
+			if tc.expectLight && result.LightnessDelta <= 0 {
+				t.Errorf("Expected foreground to lighten, delta %.2f", result.LightnessDelta)
+			}
+			if !tc.expectLight && result.LightnessDelta >= 0 {
+				t.Errorf("Expected foreground to darken, delta %.2f", result.LightnessDelta)
+			}
+
+			// Hue should be preserved by holding a*/b* fixed
+			before := formats.RGBAToHSLA(result.Original).H
+			after := formats.RGBAToHSLA(result.Adjusted).H
+			diff := math.Abs(before - after)
+			if diff > 180 {
+				diff = 360 - diff
+			}
+			t.Logf("Hue: %.1f° -> %.1f° (drift %.1f°)", before, after, diff)
+			if diff > 10 {
+				t.Errorf("Hue drifted %.1f° during adjustment", diff)
+			}
+		})
+	}
+}
+
+func TestEnforceContrast_MinimalChange(t *testing.T) {
+	fg := color.RGBA{R: 80, G: 80, B: 80, A: 255}
+	bg := color.RGBA{R: 20, G: 20, B: 20, A: 255}
+
+	result := chromatic.EnforceContrast(fg, bg, chromatic.AA)
+
+	t.Logf("Adjusted %s -> %s, ratio %.3f", formats.ToHex(fg), formats.ToHex(result.Adjusted), result.AdjustedRatio)
+
+	// The solver should stop just past the threshold rather than jump to white
+	if result.AdjustedRatio > chromatic.AA.Ratio()+0.25 {
+		t.Errorf("Expected minimal adjustment near %.1f, got ratio %.3f", chromatic.AA.Ratio(), result.AdjustedRatio)
+	}
+	if result.Adjusted.A != fg.A {
+		t.Errorf("Expected alpha preserved, got %d", result.Adjusted.A)
+	}
+}
+
+func TestEnforceContrastPairs(t *testing.T) {
+	bg := color.RGBA{R: 24, G: 24, B: 32, A: 255}
+	pairs := []chromatic.ContrastPair{
+		{Name: "foreground", Foreground: color.RGBA{R: 200, G: 200, B: 210, A: 255}, Background: bg},
+		{Name: "comment", Foreground: color.RGBA{R: 60, G: 60, B: 70, A: 255}, Background: bg, Level: chromatic.AALarge},
+		{Name: "keyword", Foreground: color.RGBA{R: 100, G: 40, B: 160, A: 255}, Background: bg, Level: chromatic.AA},
+	}
+
+	adjusted, report := chromatic.EnforceContrastPairs(pairs)
+
+	if len(adjusted) != len(pairs) || len(report.Adjustments) != len(pairs) {
+		t.Fatalf("Expected %d results, got %d pairs and %d adjustments", len(pairs), len(adjusted), len(report.Adjustments))
+	}
+
+	for i, a := range report.Adjustments {
+		t.Logf("%s [%s]: %s -> %s, %.2f -> %.2f", a.Name, a.Level,
+			formats.ToHex(a.Original), formats.ToHex(a.Adjusted), a.OriginalRatio, a.AdjustedRatio)
+
+		if a.Name != pairs[i].Name {
+			t.Errorf("Adjustment %d: expected name %s, got %s", i, pairs[i].Name, a.Name)
+		}
+		if adjusted[i].Foreground != a.Adjusted {
+			t.Errorf("Adjustment %d: pair foreground does not match report", i)
+		}
+		if !chromatic.IsAccessible(adjusted[i].Foreground, bg, adjusted[i].Level) {
+			t.Errorf("%s still fails %s after enforcement", a.Name, adjusted[i].Level)
+		}
+	}
+
+	if adjusted[0].Level != chromatic.AA {
+		t.Errorf("Expected empty level to default to AA, got %s", adjusted[0].Level)
+	}
+
+	if moved := report.Moved(); len(moved) != 2 {
+		t.Errorf("Expected 2 moved pairs, got %d", len(moved))
+	}
+	if failures := report.Failures(); len(failures) != 0 {
+		t.Errorf("Expected no failures, got %d", len(failures))
+	}
+}
+
+// TestContrastReport_APCA tests that adjustments carry APCA scores and that
+// pairs passing WCAG 2 can still be reported below an APCA level
+func TestContrastReport_APCA(t *testing.T) {
+	bg := color.RGBA{R: 0, G: 0, B: 0, A: 255}
+	pairs := []chromatic.ContrastPair{
+		{Name: "foreground", Foreground: color.RGBA{R: 230, G: 230, B: 230, A: 255}, Background: bg},
+		{Name: "comment", Foreground: color.RGBA{R: 118, G: 118, B: 118, A: 255}, Background: bg},
+	}
+
+	_, report := chromatic.EnforceContrastPairs(pairs)
+
+	for _, a := range report.Adjustments {
+		t.Logf("%s: %.2f:1, Lc %.2f -> %.2f", a.Name, a.AdjustedRatio, a.OriginalLc, a.AdjustedLc)
+		if a.AdjustedLc != chromatic.APCAContrast(a.Adjusted, a.Background) {
+			t.Errorf("%s: AdjustedLc does not match APCAContrast", a.Name)
+		}
+		if a.AdjustedLc >= 0 {
+			t.Errorf("%s: expected negative Lc for light text on black, got %.2f", a.Name, a.AdjustedLc)
+		}
+	}
+
+	if failures := report.Failures(); len(failures) != 0 {
+		t.Errorf("Expected both pairs to pass WCAG AA, got %d failures", len(failures))
+	}
+
+	below := report.BelowAPCA(chromatic.APCABody)
+	if len(below) != 1 || below[0].Name != "comment" {
+		t.Errorf("Expected only comment below %s, got %d", chromatic.APCABody, len(below))
+	}
+
+	if _, ok := report.Find("foreground"); !ok {
+		t.Error("Expected to find foreground adjustment")
+	}
+	if _, ok := report.Find("missing"); ok {
+		t.Error("Expected missing pair not to be found")
+	}
+}
@@ -0,0 +1,142 @@
+package formats_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// TestComponentEncoders tests every per-component encoder against the
+// examples documented in OMARCHY.md
+func TestComponentEncoders(t *testing.T) {
+	surface := color.RGBA{R: 36, G: 39, B: 58, A: 255}   // #24273a
+	border := color.RGBA{R: 198, G: 208, B: 245, A: 238} // #c6d0f5ee
+	chromium := color.RGBA{R: 239, G: 241, B: 245, A: 255}
+
+	testCases := []struct {
+		name     string
+		encode   func(color.RGBA) string
+		input    color.RGBA
+		expected string
+	}{
+		{"Lowercase hex", formats.ToHexLower, surface, "#24273a"},
+		{"Lowercase hex ignores alpha", formats.ToHexLower, border, "#c6d0f5"},
+		{"Bare hex", formats.ToBareHex, border, "c6d0f5"},
+		{"Quoted hex", formats.ToQuotedHex, surface, `"#24273a"`},
+		{"Hyprland rgb", formats.ToHyprRGB, border, "rgb(c6d0f5)"},
+		{"Hyprland rgba", formats.ToHyprRGBA, border, "rgba(c6d0f5ee)"},
+		{"Decimal rgba opaque", formats.ToDecimalRGBA, surface, "rgba(36, 39, 58, 1.0)"},
+		{"Decimal rgba translucent", formats.ToDecimalRGBA, formats.WithAlpha(surface, 0.8), "rgba(36, 39, 58, 0.8)"},
+		{"Decimal rgba rounded", formats.ToDecimalRGBA, border, "rgba(198, 208, 245, 0.93)"},
+		{"Decimal rgba transparent", formats.ToDecimalRGBA, formats.WithAlpha(surface, 0), "rgba(36, 39, 58, 0.0)"},
+		{"Chromium", formats.ToChromiumRGB, chromium, "239,241,245"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.encode(tc.input)
+			t.Logf("%v -> %s", tc.input, result)
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestComponentParsers tests that each parser reads its documented format
+func TestComponentParsers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parse    func(string) (color.RGBA, error)
+		input    string
+		expected color.RGBA
+	}{
+		{"Quoted hex", formats.ParseQuotedHex, `"#24273a"`, color.RGBA{R: 36, G: 39, B: 58, A: 255}},
+		{"Single quoted hex", formats.ParseQuotedHex, `'#24273A'`, color.RGBA{R: 36, G: 39, B: 58, A: 255}},
+		{"Hyprland rgb", formats.ParseHyprColor, "rgb(c6d0f5)", color.RGBA{R: 198, G: 208, B: 245, A: 255}},
+		{"Hyprland rgba", formats.ParseHyprColor, "rgba(c6d0f5ee)", color.RGBA{R: 198, G: 208, B: 245, A: 238}},
+		{"Decimal rgba", formats.ParseDecimalRGBA, "rgba(36, 39, 58, 1.0)", color.RGBA{R: 36, G: 39, B: 58, A: 255}},
+		{"Decimal rgba translucent", formats.ParseDecimalRGBA, "rgba(36,39,58,0.8)", color.RGBA{R: 36, G: 39, B: 58, A: 204}},
+		{"Decimal rgb", formats.ParseDecimalRGBA, "rgb(36, 39, 58)", color.RGBA{R: 36, G: 39, B: 58, A: 255}},
+		{"Chromium", formats.ParseChromiumRGB, "239,241,245", color.RGBA{R: 239, G: 241, B: 245, A: 255}},
+		{"Chromium with spaces", formats.ParseChromiumRGB, " 239, 241, 245 ", color.RGBA{R: 239, G: 241, B: 245, A: 255}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.parse(tc.input)
+			if err != nil {
+				t.Fatalf("Parse %q failed: %v", tc.input, err)
+			}
+			t.Logf("%s -> %v", tc.input, result)
+			if result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestComponentParsers_Invalid tests that malformed inputs are rejected
+func TestComponentParsers_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		parse func(string) (color.RGBA, error)
+		input string
+	}{
+		{"Unquoted", formats.ParseQuotedHex, "#24273a"},
+		{"Mismatched quotes", formats.ParseQuotedHex, `"#24273a'`},
+		{"Hyprland rgb with alpha", formats.ParseHyprColor, "rgb(c6d0f5ee)"},
+		{"Hyprland rgba without alpha", formats.ParseHyprColor, "rgba(c6d0f5)"},
+		{"Hyprland missing paren", formats.ParseHyprColor, "rgb(c6d0f5"},
+		{"Hyprland unknown function", formats.ParseHyprColor, "hsl(c6d0f5)"},
+		{"Decimal channel range", formats.ParseDecimalRGBA, "rgba(256, 39, 58, 1.0)"},
+		{"Decimal alpha range", formats.ParseDecimalRGBA, "rgba(36, 39, 58, 1.5)"},
+		{"Decimal missing alpha", formats.ParseDecimalRGBA, "rgba(36, 39, 58)"},
+		{"Decimal non-numeric", formats.ParseDecimalRGBA, "rgb(a, b, c)"},
+		{"Chromium too few", formats.ParseChromiumRGB, "239,241"},
+		{"Chromium negative", formats.ParseChromiumRGB, "-1,241,245"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.parse(tc.input)
+			if err == nil {
+				t.Errorf("Expected error for %q", tc.input)
+			} else {
+				t.Logf("%q rejected: %v", tc.input, err)
+			}
+		})
+	}
+}
+
+// TestParseColor_DetectsFormat tests that ParseColor reads every encoder's output
+// back to the original color
+func TestParseColor_DetectsFormat(t *testing.T) {
+	original := color.RGBA{R: 198, G: 208, B: 245, A: 255}
+
+	encoders := map[string]func(color.RGBA) string{
+		"ToHex":         formats.ToHex,
+		"ToHexLower":    formats.ToHexLower,
+		"ToBareHex":     formats.ToBareHex,
+		"ToQuotedHex":   formats.ToQuotedHex,
+		"ToHyprRGB":     formats.ToHyprRGB,
+		"ToHyprRGBA":    formats.ToHyprRGBA,
+		"ToDecimalRGBA": formats.ToDecimalRGBA,
+		"ToChromiumRGB": formats.ToChromiumRGB,
+	}
+
+	for name, encode := range encoders {
+		t.Run(name, func(t *testing.T) {
+			encoded := encode(original)
+			parsed, err := formats.ParseColor(encoded)
+			if err != nil {
+				t.Fatalf("ParseColor(%q) failed: %v", encoded, err)
+			}
+			t.Logf("%s -> %v", encoded, parsed)
+			if parsed != original {
+				t.Errorf("Round trip through %s: expected %v, got %v", name, original, parsed)
+			}
+		})
+	}
+}
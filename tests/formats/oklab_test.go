@@ -0,0 +1,167 @@
+package formats_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+)
+
+// TestRGBAToOKLab tests conversion against reference values from the OKLab
+// specification
+func TestRGBAToOKLab(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    color.RGBA
+		expected formats.OKLab
+	}{
+		{"White", color.RGBA{255, 255, 255, 255}, formats.OKLab{L: 1.0, A: 0, B: 0}},
+		{"Black", color.RGBA{0, 0, 0, 255}, formats.OKLab{L: 0, A: 0, B: 0}},
+		{"Red", color.RGBA{255, 0, 0, 255}, formats.OKLab{L: 0.62796, A: 0.22486, B: 0.12585}},
+		{"Green", color.RGBA{0, 255, 0, 255}, formats.OKLab{L: 0.86644, A: -0.23389, B: 0.17950}},
+		{"Blue", color.RGBA{0, 0, 255, 255}, formats.OKLab{L: 0.45201, A: -0.03246, B: -0.31153}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lab := formats.RGBAToOKLab(tc.input)
+
+			t.Logf("Input: %+v", tc.input)
+			t.Logf("Result: %s", lab)
+			t.Logf("Expected: %s", tc.expected)
+
+			if math.Abs(lab.L-tc.expected.L) > 0.001 ||
+				math.Abs(lab.A-tc.expected.A) > 0.001 ||
+				math.Abs(lab.B-tc.expected.B) > 0.001 {
+				t.Errorf("Expected %s, got %s", tc.expected, lab)
+			}
+		})
+	}
+}
+
+// TestRGBAToOKLCH tests cylindrical conversion and achromatic hue handling
+func TestRGBAToOKLCH(t *testing.T) {
+	red := formats.RGBAToOKLCH(color.RGBA{255, 0, 0, 255})
+	t.Logf("Red: %s", red)
+	if math.Abs(red.C-0.25768) > 0.001 || math.Abs(red.H-29.23) > 0.1 {
+		t.Errorf("Expected OKLCH(0.628, 0.258, 29.23), got %s", red)
+	}
+
+	gray := formats.RGBAToOKLCH(color.RGBA{128, 128, 128, 255})
+	t.Logf("Gray: %s", gray)
+	if gray.C > 1e-4 || gray.H != 0 {
+		t.Errorf("Expected achromatic gray with zero chroma and hue, got %s", gray)
+	}
+}
+
+// TestOKLab_RoundTrip tests that sRGB colors survive conversion through
+// OKLab and OKLCH
+func TestOKLab_RoundTrip(t *testing.T) {
+	failures := 0
+	for r := 0; r <= 255; r += 17 {
+		for g := 0; g <= 255; g += 17 {
+			for b := 0; b <= 255; b += 17 {
+				original := color.RGBA{uint8(r), uint8(g), uint8(b), 255}
+
+				viaLab := formats.OKLabToRGBA(formats.RGBAToOKLab(original))
+				viaLCH := formats.OKLCHToRGBA(formats.RGBAToOKLCH(original))
+
+				if viaLab != original || viaLCH != original {
+					failures++
+					if failures <= 5 {
+						t.Errorf("Round trip %v: OKLab %v, OKLCH %v", original, viaLab, viaLCH)
+					}
+				}
+			}
+		}
+	}
+	t.Logf("Round trip failures: %d of %d", failures, 16*16*16)
+}
+
+// TestOKLCHToRGBA_GamutMapping tests that out-of-gamut colors keep their
+// lightness and hue while chroma is reduced
+func TestOKLCHToRGBA_GamutMapping(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input formats.OKLCH
+	}{
+		{"Saturated cyan", formats.NewOKLCH(0.8, 0.3, 200)},
+		{"Dark violet", formats.NewOKLCH(0.3, 0.35, 300)},
+		{"Light yellow", formats.NewOKLCH(0.95, 0.3, 100)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.input.InGamut() {
+				t.Fatalf("Test color %s should be out of gamut", tc.input)
+			}
+
+			rgba := formats.OKLCHToRGBA(tc.input)
+			mapped := formats.RGBAToOKLCH(rgba)
+
+			t.Logf("Input: %s", tc.input)
+			t.Logf("Mapped: %s (%s)", mapped, formats.ToHex(rgba))
+
+			if math.Abs(mapped.L-tc.input.L) > 0.01 {
+				t.Errorf("Lightness drifted from %.3f to %.3f", tc.input.L, mapped.L)
+			}
+			if d := math.Abs(mapped.H - tc.input.H); math.Min(d, 360-d) > 3 {
+				t.Errorf("Hue drifted from %.1f° to %.1f°", tc.input.H, mapped.H)
+			}
+			if mapped.C >= tc.input.C {
+				t.Errorf("Expected reduced chroma, got %.3f from %.3f", mapped.C, tc.input.C)
+			}
+		})
+	}
+}
+
+// TestOKLCH_PerceptualLightness tests that equal HSL lightness is not equal
+// perceived lightness, while equal OKLCH lightness is
+func TestOKLCH_PerceptualLightness(t *testing.T) {
+	yellow := formats.HSLAToRGBA(formats.NewHSL(60, 1, 0.5))
+	blue := formats.HSLAToRGBA(formats.NewHSL(240, 1, 0.5))
+
+	ly := formats.RGBAToOKLCH(yellow).L
+	lb := formats.RGBAToOKLCH(blue).L
+	t.Logf("HSL L=0.5: yellow OKLCH L=%.3f, blue OKLCH L=%.3f", ly, lb)
+
+	if ly-lb < 0.4 {
+		t.Errorf("Expected HSL yellow to be far lighter than HSL blue, got %.3f vs %.3f", ly, lb)
+	}
+
+	base := formats.NewOKLCH(0.7, 0.1, 60)
+	for _, degrees := range []float64{90, 180, 270} {
+		rotated := base.Rotate(degrees)
+		actual := formats.RGBAToOKLCH(formats.OKLCHToRGBA(rotated))
+		t.Logf("Rotated %.0f°: %s -> %s", degrees, rotated, actual)
+		if math.Abs(actual.L-base.L) > 0.01 {
+			t.Errorf("Rotation by %.0f° changed lightness to %.3f", degrees, actual.L)
+		}
+	}
+}
+
+// TestOKLab_ColorInterface tests that OKLab and OKLCH satisfy color.Color
+func TestOKLab_ColorInterface(t *testing.T) {
+	var colors = []color.Color{
+		formats.NewOKLab(0.62796, 0.22486, 0.12585),
+		formats.NewOKLCH(0.62796, 0.25768, 29.23),
+	}
+
+	for _, c := range colors {
+		r, g, b, a := c.RGBA()
+		t.Logf("%v -> %d %d %d %d", c, r>>8, g>>8, b>>8, a>>8)
+		if r>>8 != 255 || g>>8 > 1 || b>>8 > 1 || a>>8 != 255 {
+			t.Errorf("Expected opaque red from %v", c)
+		}
+	}
+}
+
+// TestNewOKLCH tests hue normalization and clamping
+func TestNewOKLCH(t *testing.T) {
+	c := formats.NewOKLCH(1.2, -0.1, -30)
+	t.Logf("NewOKLCH(1.2, -0.1, -30) = %s", c)
+	if c.L != 1 || c.C != 0 || c.H != 330 {
+		t.Errorf("Expected OKLCH(1, 0, 330), got %s", c)
+	}
+}
@@ -0,0 +1,224 @@
+package integration_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/theme"
+)
+
+// TestCLI_Generate builds the omarchy-theme-gen binary and generates themes
+// from test images through the complete loader → processor → palette → theme
+// pipeline
+func TestCLI_Generate(t *testing.T) {
+	bin := buildCLI(t)
+	out := t.TempDir()
+
+	testCases := []struct {
+		name      string
+		image     string
+		mode      string
+		lightMode bool
+	}{
+		{"Detected mode", "night-city.jpeg", "", false},
+		{"Forced light mode", "night-city.jpeg", "light", true},
+		{"Forced dark mode", "mountains.jpeg", "dark", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := "cli-" + string(rune('a'+i))
+			args := []string{"generate", "--image", filepath.Join("..", "images", tc.image), "--name", name, "--out", out}
+			if tc.mode != "" {
+				args = append(args, "--mode", tc.mode)
+			}
+
+			stdout, stderr, err := runCLI(t, bin, args...)
+			t.Logf("stdout:\n%s", stdout)
+			if err != nil {
+				t.Fatalf("generate failed: %v\nstderr: %s", err, stderr)
+			}
+
+			dir := filepath.Join(out, name)
+			for _, file := range append(theme.ComponentFiles, theme.MetadataFile) {
+				if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+					t.Errorf("Missing %s: %v", file, err)
+				}
+			}
+
+			_, err = os.Stat(filepath.Join(dir, theme.LightModeFile))
+			if tc.lightMode && err != nil {
+				t.Errorf("Expected %s for light theme", theme.LightModeFile)
+			}
+			if !tc.lightMode && err == nil {
+				t.Errorf("Unexpected %s for dark theme", theme.LightModeFile)
+			}
+		})
+	}
+}
+
+// TestCLI_GenerateErrors tests that invalid invocations fail with a message
+func TestCLI_GenerateErrors(t *testing.T) {
+	bin := buildCLI(t)
+	out := t.TempDir()
+	image := filepath.Join("..", "images", "simple.png")
+
+	if _, stderr, err := runCLI(t, bin, "generate", "--image", image, "--name", "dup", "--out", out); err != nil {
+		t.Fatalf("Initial generate failed: %v\n%s", err, stderr)
+	}
+
+	testCases := []struct {
+		name     string
+		args     []string
+		contains string
+	}{
+		{"Missing name", []string{"generate", "--image", image}, `"name" not set`},
+		{"Invalid mode", []string{"generate", "--image", image, "--name", "x", "--out", out, "--mode", "sepia"}, "invalid theme mode"},
+		{"Invalid scheme", []string{"generate", "--image", image, "--name", "x", "--out", out, "--scheme", "pastel"}, palette.SchemeNames()},
+		{"Missing image", []string{"generate", "--image", "missing.png", "--name", "x", "--out", out}, "missing.png"},
+		{"Existing theme", []string{"generate", "--image", image, "--name", "dup", "--out", out}, "already exists"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, stderr, err := runCLI(t, bin, tc.args...)
+			t.Logf("stderr: %s", stderr)
+			if err == nil {
+				t.Fatalf("Expected failure")
+			}
+			if !strings.Contains(stderr, tc.contains) {
+				t.Errorf("Expected stderr to contain %q", tc.contains)
+			}
+		})
+	}
+
+	if _, stderr, err := runCLI(t, bin, "generate", "--image", image, "--name", "dup", "--out", out, "--force"); err != nil {
+		t.Errorf("--force should replace existing theme: %v\n%s", err, stderr)
+	}
+}
+
+// TestCLI_EditCommands tests set-scheme, set-mode, and clone against a
+// generated theme without re-reading the source image
+func TestCLI_EditCommands(t *testing.T) {
+	bin := buildCLI(t)
+	out := t.TempDir()
+	image := filepath.Join("..", "images", "night-city.jpeg")
+
+	if _, stderr, err := runCLI(t, bin, "generate", "--image", image, "--name", "base", "--out", out); err != nil {
+		t.Fatalf("Initial generate failed: %v\n%s", err, stderr)
+	}
+
+	s := settings.DefaultSettings()
+	s.Theme.ThemesDir = out
+	w := theme.New(s)
+
+	base, err := w.LoadMetadata("base")
+	if err != nil {
+		t.Fatalf("Load metadata: %v", err)
+	}
+	t.Logf("Detected scheme %s (%.2f)", base.Analysis.DetectedScheme, base.Analysis.SchemeConfidence)
+	if base.Generation.Scheme != base.Analysis.DetectedScheme {
+		t.Errorf("Expected generate to default to detected scheme %s, got %s",
+			base.Analysis.DetectedScheme, base.Generation.Scheme)
+	}
+
+	testCases := []struct {
+		name   string
+		args   []string
+		theme  string
+		scheme string
+		mode   string
+	}{
+		{"Set scheme", []string{"set-scheme", "base", "--scheme", "triadic"}, "base", "triadic", "dark"},
+		{"Set mode", []string{"set-mode", "base", "--mode", "light"}, "base", "triadic", "light"},
+		{"Clone", []string{"clone", "base", "copy", "--scheme", "monochromatic", "--mode", "dark"}, "copy", "monochromatic", "dark"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr, err := runCLI(t, bin, append(tc.args, "--themes-dir", out)...)
+			t.Logf("stdout:\n%s", stdout)
+			if err != nil {
+				t.Fatalf("%s failed: %v\nstderr: %s", tc.args[0], err, stderr)
+			}
+
+			meta, err := w.LoadMetadata(tc.theme)
+			if err != nil {
+				t.Fatalf("Load metadata: %v", err)
+			}
+
+			if meta.Generation.Scheme != tc.scheme {
+				t.Errorf("Expected scheme %s, got %s", tc.scheme, meta.Generation.Scheme)
+			}
+			if meta.Generation.Mode != tc.mode {
+				t.Errorf("Expected mode %s, got %s", tc.mode, meta.Generation.Mode)
+			}
+
+			_, err = os.Stat(filepath.Join(out, tc.theme, theme.LightModeFile))
+			if (tc.mode == "light") != (err == nil) {
+				t.Errorf("%s presence does not match mode %s", theme.LightModeFile, tc.mode)
+			}
+		})
+	}
+
+	errorCases := []struct {
+		name     string
+		args     []string
+		contains string
+	}{
+		{"Invalid scheme", []string{"set-scheme", "base", "--scheme", "pentadic"}, "invalid scheme"},
+		{"Missing theme", []string{"set-mode", "missing", "--mode", "dark"}, "missing"},
+		{"Clone exists", []string{"clone", "base", "copy"}, "already exists"},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, stderr, err := runCLI(t, bin, append(tc.args, "--themes-dir", out)...)
+			t.Logf("stderr: %s", stderr)
+			if err == nil {
+				t.Fatalf("Expected failure")
+			}
+			if !strings.Contains(stderr, tc.contains) {
+				t.Errorf("Expected stderr to contain %q", tc.contains)
+			}
+		})
+	}
+}
+
+// Helper functions
+
+func buildCLI(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping CLI build in short mode")
+	}
+
+	bin := filepath.Join(t.TempDir(), "omarchy-theme-gen")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+
+	cmd := exec.Command("go", "build", "-o", bin, "../../cmd/omarchy-theme-gen")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Build CLI: %v\n%s", err, output)
+	}
+	return bin
+}
+
+func runCLI(t *testing.T, bin string, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(bin, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "OMARCHY_CONFIG=")
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
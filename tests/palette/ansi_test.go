@@ -0,0 +1,169 @@
+package palette_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+func TestANSISlot_Hue(t *testing.T) {
+	expected := map[palette.ANSISlot]float64{
+		palette.ANSIRed:     0,
+		palette.ANSIYellow:  60,
+		palette.ANSIGreen:   120,
+		palette.ANSICyan:    180,
+		palette.ANSIBlue:    240,
+		palette.ANSIMagenta: 300,
+	}
+
+	for slot, hue := range expected {
+		t.Logf("Slot %s: hue %.0f°", slot, slot.Hue())
+		if slot.Hue() != hue {
+			t.Errorf("Slot %s: expected hue %.0f°, got %.0f°", slot, hue, slot.Hue())
+		}
+	}
+}
+
+func TestBuildANSI_SnapsClustersToSlots(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	red := color.RGBA{R: 220, G: 70, B: 80, A: 255}    // ~356°
+	green := color.RGBA{R: 90, G: 200, B: 100, A: 255} // ~125°
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 24, G: 26, B: 36, A: 255}, 0.5),
+			newCluster(s, color.RGBA{R: 225, G: 228, B: 235, A: 255}, 0.2),
+			newCluster(s, red, 0.15),
+			newCluster(s, green, 0.15),
+		},
+		HasColor:   true,
+		ColorCount: 4,
+	}
+
+	terminal, err := g.BuildANSI(profile)
+	if err != nil {
+		t.Fatalf("BuildANSI failed: %v", err)
+	}
+
+	logANSI(t, terminal)
+
+	redHue := formats.RGBAToHSLA(terminal.Normal.Red).H
+	if d := hueDistance(redHue, formats.RGBAToHSLA(red).H); d > 2 {
+		t.Errorf("Expected red slot to keep extracted hue, drifted %.1f°", d)
+	}
+
+	greenHue := formats.RGBAToHSLA(terminal.Normal.Green).H
+	if d := hueDistance(greenHue, formats.RGBAToHSLA(green).H); d > 2 {
+		t.Errorf("Expected green slot to keep extracted hue, drifted %.1f°", d)
+	}
+
+	// Synthesized slots should sit near their canonical hue
+	synthesized := map[palette.ANSISlot]color.RGBA{
+		palette.ANSIYellow:  terminal.Normal.Yellow,
+		palette.ANSICyan:    terminal.Normal.Cyan,
+		palette.ANSIBlue:    terminal.Normal.Blue,
+		palette.ANSIMagenta: terminal.Normal.Magenta,
+	}
+	for slot, c := range synthesized {
+		h := formats.RGBAToHSLA(c)
+		if d := hueDistance(h.H, slot.Hue()); d > 3 {
+			t.Errorf("Synthesized %s hue %.1f° too far from canonical %.0f°", slot, h.H, slot.Hue())
+		}
+		if h.S < s.Palette.ANSISaturationMin-0.02 {
+			t.Errorf("Synthesized %s saturation %.3f below minimum %.3f", slot, h.S, s.Palette.ANSISaturationMin)
+		}
+	}
+}
+
+func TestBuildANSI_BrightVariants(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 20, G: 22, B: 30, A: 255}, 0.7),
+			newCluster(s, color.RGBA{R: 60, G: 120, B: 200, A: 255}, 0.3),
+		},
+		HasColor:   true,
+		ColorCount: 2,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	logANSI(t, pal.Terminal)
+
+	colors := pal.Terminal.Colors()
+	for i := 1; i < 7; i++ {
+		normal := formats.RGBAToHSLA(colors[i])
+		bright := formats.RGBAToHSLA(colors[i+8])
+		if bright.L < normal.L {
+			t.Errorf("ANSI %d: bright lightness %.3f below normal %.3f", i+8, bright.L, normal.L)
+		}
+	}
+
+	if chromaticLightness(pal.Terminal.Normal.Black) >= chromaticLightness(pal.Terminal.Normal.White) {
+		t.Errorf("Expected black to be darker than white")
+	}
+	if pal.Terminal.Bright.White != pal.Foreground {
+		t.Errorf("Expected bright white to match foreground in dark mode")
+	}
+}
+
+func TestBuildANSI_GrayscaleProfile(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Light,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 235, G: 235, B: 235, A: 255}, 0.8),
+			newCluster(s, color.RGBA{R: 40, G: 40, B: 40, A: 255}, 0.2),
+		},
+		HasColor:   false,
+		ColorCount: 2,
+	}
+
+	terminal, err := g.BuildANSI(profile)
+	if err != nil {
+		t.Fatalf("BuildANSI failed: %v", err)
+	}
+
+	logANSI(t, terminal)
+
+	maxL := 1 - s.Palette.ANSILightnessMin
+	for i, c := range []color.RGBA{terminal.Normal.Red, terminal.Normal.Green, terminal.Normal.Blue} {
+		h := formats.RGBAToHSLA(c)
+		if h.S < s.Palette.ANSISaturationMin-0.02 {
+			t.Errorf("Color %d: expected synthesized saturation ≥ %.2f, got %.3f", i, s.Palette.ANSISaturationMin, h.S)
+		}
+		if h.L > maxL+0.01 {
+			t.Errorf("Color %d: expected light-mode lightness ≤ %.2f, got %.3f", i, maxL, h.L)
+		}
+	}
+}
+
+// Helper functions
+
+func chromaticLightness(c color.RGBA) float64 {
+	return formats.RGBAToHSLA(c).L
+}
+
+func logANSI(t *testing.T, p palette.ANSIPalette) {
+	t.Helper()
+	names := []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+	colors := p.Colors()
+	for i, name := range names {
+		t.Logf("  %-8s normal=%s bright=%s", name, formats.ToHex(colors[i]), formats.ToHex(colors[i+8]))
+	}
+}
@@ -0,0 +1,68 @@
+package palette_test
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// TestBuild_ReportsDeficiencies tests that terminal red and green are flagged
+// when they collapse for red-green color blindness, and that pairs are only
+// compared within a group
+func TestBuild_ReportsDeficiencies(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 30, G: 32, B: 48, A: 255}, 0.5),
+			newCluster(s, color.RGBA{R: 220, G: 224, B: 232, A: 255}, 0.2),
+			newCluster(s, color.RGBA{R: 200, G: 70, B: 60, A: 255}, 0.15),
+			newCluster(s, color.RGBA{R: 80, G: 170, B: 70, A: 255}, 0.15),
+		},
+		HasColor:   true,
+		ColorCount: 4,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, c := range pal.Deficiencies.Conflicts {
+		t.Logf("%s: %s/%s %.1f -> %.1f", c.Deficiency, c.First.Name, c.Second.Name, c.Original, c.Simulated)
+	}
+
+	found := false
+	for _, c := range pal.Deficiencies.For(chromatic.Deuteranopia) {
+		if c.First.Name == "normal.red" && c.Second.Name == "normal.green" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected normal.red and normal.green to collapse under deuteranopia")
+	}
+
+	group := func(name string) string {
+		if i := strings.Index(name, "."); i >= 0 {
+			return name[:i]
+		}
+		return "accent"
+	}
+	for _, c := range pal.Deficiencies.Conflicts {
+		if group(c.First.Name) != group(c.Second.Name) {
+			t.Errorf("Compared %s with %s across groups", c.First.Name, c.Second.Name)
+		}
+		for _, name := range []string{c.First.Name, c.Second.Name} {
+			if strings.HasSuffix(name, ".black") || strings.HasSuffix(name, ".white") {
+				t.Errorf("Unexpected neutral %s in conflict %s/%s", name, c.First.Name, c.Second.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package palette_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
+	themeerrors "github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+func TestBuild_DarkProfile(t *testing.T) {
+	s := settings.DefaultSettings()
+	p := processor.New(s)
+
+	img := createWeightedImage([]weightedColor{
+		{color.RGBA{R: 30, G: 32, B: 48, A: 255}, 50},    // Dark navy background
+		{color.RGBA{R: 220, G: 224, B: 232, A: 255}, 20}, // Light gray text
+		{color.RGBA{R: 230, G: 80, B: 80, A: 255}, 12},   // Red accent
+		{color.RGBA{R: 80, G: 200, B: 120, A: 255}, 10},  // Green accent
+		{color.RGBA{R: 90, G: 140, B: 240, A: 255}, 8},   // Blue accent
+	})
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	pal, err := palette.New(s).Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	if pal.Mode != processor.Dark {
+		t.Errorf("Expected Dark mode, got %s", pal.Mode)
+	}
+
+	bg := formats.RGBAToHSLA(pal.Background)
+	fg := formats.RGBAToHSLA(pal.Foreground)
+
+	if bg.L >= s.Chromatic.DarkLightnessMax {
+		t.Errorf("Expected dark background, got lightness %.3f", bg.L)
+	}
+	if fg.L <= s.Chromatic.LightLightnessMin {
+		t.Errorf("Expected light foreground, got lightness %.3f", fg.L)
+	}
+
+	accents := pal.Accents()
+	for i := 0; i < len(accents); i++ {
+		for j := i + 1; j < len(accents); j++ {
+			hi := formats.RGBAToHSLA(accents[i]).H
+			hj := formats.RGBAToHSLA(accents[j]).H
+			if d := hueDistance(hi, hj); d < s.Palette.AccentHueSeparation {
+				t.Errorf("Accents %d and %d too close in hue: %.1f° < %.1f°", i, j, d, s.Palette.AccentHueSeparation)
+			}
+		}
+	}
+
+	if pal.Cursor != pal.Primary || pal.Border != pal.Primary {
+		t.Errorf("Expected cursor and border to use primary accent")
+	}
+}
+
+func TestBuild_LightProfile(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Light,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 240, G: 238, B: 230, A: 255}, 0.6),
+			newCluster(s, color.RGBA{R: 40, G: 42, B: 50, A: 255}, 0.2),
+			newCluster(s, color.RGBA{R: 200, G: 120, B: 40, A: 255}, 0.2),
+		},
+		HasColor:   true,
+		ColorCount: 3,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	if formats.RGBAToHSLA(pal.Background).L <= formats.RGBAToHSLA(pal.Foreground).L {
+		t.Errorf("Expected light background to be lighter than foreground")
+	}
+
+	// Primary may be darkened for contrast but keeps the extracted hue
+	expected := formats.RGBAToHSLA(profile.Colors[2].RGBA).H
+	if d := hueDistance(formats.RGBAToHSLA(pal.Primary).H, expected); d > 3 {
+		t.Errorf("Expected primary hue %.1f°, got %s (drift %.1f°)", expected, formats.ToHex(pal.Primary), d)
+	}
+}
+
+func TestBuild_SynthesizesMissingRoles(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	// Only a single mid-lightness vibrant color: no dark or light clusters
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 40, G: 120, B: 200, A: 255}, 1.0),
+		},
+		HasColor:   true,
+		ColorCount: 1,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	bg := formats.RGBAToHSLA(pal.Background)
+	if diff := bg.L - s.Palette.DarkBackgroundLightness; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Expected synthesized background lightness %.2f, got %.3f", s.Palette.DarkBackgroundLightness, bg.L)
+	}
+	if bg.S > s.Palette.BackgroundSaturationMax+0.01 {
+		t.Errorf("Background saturation %.3f exceeds maximum %.3f", bg.S, s.Palette.BackgroundSaturationMax)
+	}
+
+	if pal.Secondary == pal.Primary || pal.Tertiary == pal.Primary {
+		t.Errorf("Expected derived accents to differ from primary")
+	}
+}
+
+func TestBuild_GrayscaleFallbacks(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 128, G: 128, B: 128, A: 255}, 1.0),
+		},
+		HasColor:   false,
+		ColorCount: 1,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	if formats.ToHex(pal.Background) != "#1A1A1A" {
+		t.Errorf("Expected DefaultDark background, got %s", formats.ToHex(pal.Background))
+	}
+	if formats.ToHex(pal.Foreground) != "#F0F0F0" {
+		t.Errorf("Expected DefaultLight foreground, got %s", formats.ToHex(pal.Foreground))
+	}
+
+	for i, accent := range pal.Accents() {
+		if h := formats.RGBAToHSLA(accent); h.S > 0.01 {
+			t.Errorf("Expected neutral accent %d for grayscale profile, got saturation %.3f", i, h.S)
+		}
+	}
+}
+
+func TestBuild_EnforcesContrast(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	// Low-contrast image: muted mid-dark tones with no light cluster for text
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 40, G: 44, B: 60, A: 255}, 0.6),
+			newCluster(s, color.RGBA{R: 70, G: 60, B: 120, A: 255}, 0.25),
+			newCluster(s, color.RGBA{R: 110, G: 50, B: 50, A: 255}, 0.15),
+		},
+		HasColor:   true,
+		ColorCount: 3,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	for _, a := range pal.Contrast.Moved() {
+		t.Logf("  Adjusted %-14s %s -> %s (%.2f -> %.2f)", a.Name,
+			formats.ToHex(a.Original), formats.ToHex(a.Adjusted), a.OriginalRatio, a.AdjustedRatio)
+	}
+
+	if failures := pal.Contrast.Failures(); len(failures) > 0 {
+		t.Errorf("Expected all pairs to satisfy their level, %d failed", len(failures))
+	}
+
+	text := chromatic.AccessibilityLevel(s.Palette.TextContrastLevel)
+	if !chromatic.IsAccessible(pal.Foreground, pal.Background, text) {
+		t.Errorf("Foreground fails %s: ratio %.2f", text, chromatic.ContrastRatio(pal.Foreground, pal.Background))
+	}
+
+	accent := chromatic.AccessibilityLevel(s.Palette.AccentContrastLevel)
+	for i, c := range pal.Accents() {
+		if !chromatic.IsAccessible(c, pal.Background, accent) {
+			t.Errorf("Accent %d fails %s: ratio %.2f", i, accent, chromatic.ContrastRatio(c, pal.Background))
+		}
+	}
+
+	if pal.Cursor != pal.Primary {
+		t.Errorf("Expected cursor to follow adjusted primary")
+	}
+
+	border := chromatic.AccessibilityLevel(s.Palette.BorderContrastLevel)
+	if !chromatic.IsAccessible(pal.Border, pal.Background, border) {
+		t.Errorf("Border fails %s: ratio %.2f", border, chromatic.ContrastRatio(pal.Border, pal.Background))
+	}
+}
+
+// TestBuild_EnforcesBorderContrast tests that a border level stricter than
+// the accent level moves the border away from the primary accent
+func TestBuild_EnforcesBorderContrast(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Palette.BorderContrastLevel = string(chromatic.AAA)
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 30, G: 32, B: 48, A: 255}, 0.6),
+			newCluster(s, color.RGBA{R: 220, G: 224, B: 232, A: 255}, 0.2),
+			newCluster(s, color.RGBA{R: 90, G: 70, B: 180, A: 255}, 0.2),
+		},
+		HasColor:   true,
+		ColorCount: 3,
+	}
+
+	pal, err := g.Build(profile)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	a, ok := pal.Contrast.Find("border")
+	if !ok {
+		t.Fatal("Expected border in the contrast report")
+	}
+	t.Logf("Border [%s]: %s -> %s (%.2f -> %.2f)", a.Level,
+		formats.ToHex(a.Original), formats.ToHex(a.Adjusted), a.OriginalRatio, a.AdjustedRatio)
+
+	if !a.Satisfied || !chromatic.IsAccessible(pal.Border, pal.Background, chromatic.AAA) {
+		t.Errorf("Border fails AAA: ratio %.2f", chromatic.ContrastRatio(pal.Border, pal.Background))
+	}
+	if pal.Border == pal.Primary {
+		t.Errorf("Expected border to move away from primary %s", formats.ToHex(pal.Primary))
+	}
+	if pal.Cursor != pal.Primary {
+		t.Errorf("Expected cursor to keep following primary")
+	}
+}
+
+func TestBuildWithOptions_PinsRoles(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 30, G: 32, B: 48, A: 255}, 0.6),
+			newCluster(s, color.RGBA{R: 220, G: 224, B: 232, A: 255}, 0.2),
+			newCluster(s, color.RGBA{R: 230, G: 80, B: 80, A: 255}, 0.2),
+		},
+		HasColor:   true,
+		ColorCount: 3,
+	}
+
+	// A primary that fails contrast against the background must still be kept
+	bg := color.RGBA{R: 10, G: 10, B: 20, A: 255}
+	primary := color.RGBA{R: 40, G: 20, B: 60, A: 255}
+
+	pal, err := g.BuildWithOptions(profile, palette.Options{Overrides: palette.Overrides{
+		Background: &bg,
+		Primary:    &primary,
+	}})
+	if err != nil {
+		t.Fatalf("BuildWithOptions failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	if pal.Background != bg {
+		t.Errorf("Expected pinned background %s, got %s", formats.ToHex(bg), formats.ToHex(pal.Background))
+	}
+	if pal.Primary != primary {
+		t.Errorf("Expected pinned primary %s, got %s", formats.ToHex(primary), formats.ToHex(pal.Primary))
+	}
+	if pal.Cursor != primary || pal.Border != primary {
+		t.Errorf("Expected cursor and border to follow pinned primary")
+	}
+
+	for _, a := range pal.Contrast.Adjustments {
+		if a.Name == "primary" {
+			t.Errorf("Pinned primary should not appear in the contrast report")
+		}
+		if a.Background != bg {
+			t.Errorf("%s measured against %s, expected pinned background", a.Name, formats.ToHex(a.Background))
+		}
+	}
+
+	unpinned, err := g.BuildWithOptions(profile, palette.Options{})
+	if err != nil {
+		t.Fatalf("BuildWithOptions failed: %v", err)
+	}
+	built, _ := g.Build(profile)
+	if unpinned.Primary != built.Primary || unpinned.Background != built.Background {
+		t.Errorf("Empty overrides should match Build")
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	if _, err := g.Build(nil); !errors.Is(err, themeerrors.ErrEmptyProfile) {
+		t.Errorf("Expected ErrEmptyProfile for nil profile, got %v", err)
+	}
+
+	if _, err := g.Build(&processor.ColorProfile{}); !errors.Is(err, themeerrors.ErrEmptyProfile) {
+		t.Errorf("Expected ErrEmptyProfile for empty profile, got %v", err)
+	}
+
+	s.DefaultDark = "not-a-color"
+	profile := &processor.ColorProfile{
+		Mode:   processor.Dark,
+		Colors: []processor.ColorCluster{newCluster(s, color.RGBA{R: 128, G: 128, B: 128, A: 255}, 1.0)},
+	}
+
+	_, err := g.Build(profile)
+	var palErr *themeerrors.PaletteError
+	if !errors.As(err, &palErr) {
+		t.Fatalf("Expected PaletteError for invalid fallback, got %v", err)
+	}
+	if !errors.Is(err, themeerrors.ErrInvalidFallback) {
+		t.Errorf("Expected ErrInvalidFallback, got %v", err)
+	}
+	t.Logf("Invalid fallback error: %v", err)
+
+	levels := settings.DefaultSettings()
+	levels.Palette.BorderContrastLevel = "AAA-lrg"
+	valid := &processor.ColorProfile{
+		Mode:   processor.Dark,
+		Colors: []processor.ColorCluster{newCluster(levels, color.RGBA{R: 128, G: 128, B: 128, A: 255}, 1.0)},
+	}
+	if _, err := palette.New(levels).Build(valid); err == nil {
+		t.Error("Expected an error for an unknown border contrast level")
+	} else {
+		t.Logf("Unknown contrast level error: %v", err)
+	}
+}
+
+// Helper functions
+
+type weightedColor struct {
+	color  color.RGBA
+	pixels int
+}
+
+// createWeightedImage builds a single-row image where each color occupies
+// the given number of pixels.
+func createWeightedImage(colors []weightedColor) image.Image {
+	total := 0
+	for _, wc := range colors {
+		total += wc.pixels
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, total, 1))
+	x := 0
+	for _, wc := range colors {
+		for i := 0; i < wc.pixels; i++ {
+			img.Set(x, 0, wc.color)
+			x++
+		}
+	}
+
+	return img
+}
+
+func newCluster(s *settings.Settings, c color.RGBA, weight float64) processor.ColorCluster {
+	hsla := formats.RGBAToHSLA(c)
+
+	return processor.ColorCluster{
+		RGBA:       c,
+		Weight:     weight,
+		Lightness:  hsla.L,
+		Saturation: hsla.S,
+		Hue:        hsla.H,
+		IsNeutral:  hsla.S < s.Chromatic.NeutralThreshold,
+		IsDark:     hsla.L < s.Chromatic.DarkLightnessMax,
+		IsLight:    hsla.L > s.Chromatic.LightLightnessMin,
+		IsMuted:    hsla.S < s.Chromatic.MutedSaturationMax && hsla.S >= s.Chromatic.NeutralThreshold,
+		IsVibrant:  hsla.S > s.Chromatic.VibrantSaturationMin,
+	}
+}
+
+func logPalette(t *testing.T, pal *palette.SemanticPalette) {
+	t.Helper()
+	t.Logf("SemanticPalette (%s):", pal.Mode)
+	t.Logf("  Background:    %s", formats.ToHex(pal.Background))
+	t.Logf("  Foreground:    %s", formats.ToHex(pal.Foreground))
+	t.Logf("  DimForeground: %s", formats.ToHex(pal.DimForeground))
+	t.Logf("  Cursor:        %s", formats.ToHex(pal.Cursor))
+	t.Logf("  Selection:     %s", formats.ToHex(pal.Selection))
+	t.Logf("  Border:        %s", formats.ToHex(pal.Border))
+	t.Logf("  Primary:       %s", formats.ToHex(pal.Primary))
+	t.Logf("  Secondary:     %s", formats.ToHex(pal.Secondary))
+	t.Logf("  Tertiary:      %s", formats.ToHex(pal.Tertiary))
+}
+
+func hueDistance(h1, h2 float64) float64 {
+	diff := h1 - h2
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff
+}
@@ -0,0 +1,226 @@
+package palette_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+func TestParseScheme(t *testing.T) {
+	for _, scheme := range palette.Schemes {
+		parsed, err := palette.ParseScheme(string(scheme))
+		if err != nil || parsed != scheme {
+			t.Errorf("ParseScheme(%q) = %q, %v", scheme, parsed, err)
+		}
+	}
+
+	if parsed, err := palette.ParseScheme(" Triadic "); err != nil || parsed != palette.SchemeTriadic {
+		t.Errorf("Expected case-insensitive parse, got %q, %v", parsed, err)
+	}
+	if parsed, err := palette.ParseScheme(""); err != nil || parsed != palette.SchemeExtracted {
+		t.Errorf("Expected empty scheme to be extracted, got %q, %v", parsed, err)
+	}
+
+	_, err := palette.ParseScheme("pentadic")
+	if err == nil {
+		t.Fatal("Expected error for unknown scheme")
+	}
+	t.Logf("Unknown scheme error: %v", err)
+}
+
+func TestBuildWithOptions_SchemeHues(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	// Single vibrant blue accent so every scheme hue beyond the primary is synthesized
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 24, G: 26, B: 36, A: 255}, 0.6),
+			newCluster(s, color.RGBA{R: 225, G: 228, B: 235, A: 255}, 0.2),
+			newCluster(s, color.RGBA{R: 60, G: 120, B: 220, A: 255}, 0.2),
+		},
+		HasColor:   true,
+		ColorCount: 3,
+	}
+
+	testCases := []struct {
+		scheme  palette.Scheme
+		offsets []float64
+	}{
+		{palette.SchemeComplementary, []float64{180, 30}},
+		{palette.SchemeSplitComplementary, []float64{150, 210}},
+		{palette.SchemeAnalogous, []float64{30, -30}},
+		{palette.SchemeTriadic, []float64{120, 240}},
+		{palette.SchemeTetradic, []float64{90, 180}},
+		{palette.SchemeMonochromatic, []float64{0, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.scheme), func(t *testing.T) {
+			pal, err := g.BuildWithOptions(profile, palette.Options{Scheme: tc.scheme})
+			if err != nil {
+				t.Fatalf("BuildWithOptions failed: %v", err)
+			}
+
+			logPalette(t, pal)
+
+			if pal.Scheme != tc.scheme {
+				t.Errorf("Expected palette scheme %s, got %s", tc.scheme, pal.Scheme)
+			}
+
+			primary := formats.RGBAToHSLA(pal.Primary).H
+			for i, c := range []color.RGBA{pal.Secondary, pal.Tertiary} {
+				expected := primary + tc.offsets[i]
+				if d := hueDistance(formats.RGBAToHSLA(c).H, expected); d > 3 {
+					t.Errorf("Accent %d hue %.1f° expected %.1f° (off by %.1f°)",
+						i+2, formats.RGBAToHSLA(c).H, expected, d)
+				}
+			}
+
+			if tc.scheme == palette.SchemeMonochromatic {
+				l1 := formats.RGBAToHSLA(pal.Secondary).L
+				l2 := formats.RGBAToHSLA(pal.Tertiary).L
+				if l1 == l2 {
+					t.Errorf("Expected monochromatic accents to vary in lightness, both %.3f", l1)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildWithOptions_SchemePrefersExtracted(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	orange := color.RGBA{R: 230, G: 140, B: 40, A: 255} // ~32°, near the complement of blue
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 24, G: 26, B: 36, A: 255}, 0.5),
+			newCluster(s, color.RGBA{R: 225, G: 228, B: 235, A: 255}, 0.2),
+			newCluster(s, color.RGBA{R: 60, G: 120, B: 220, A: 255}, 0.15),
+			newCluster(s, color.RGBA{R: 60, G: 200, B: 90, A: 255}, 0.1),
+			newCluster(s, orange, 0.05),
+		},
+		HasColor:   true,
+		ColorCount: 5,
+	}
+
+	pal, err := g.BuildWithOptions(profile, palette.Options{Scheme: palette.SchemeComplementary})
+	if err != nil {
+		t.Fatalf("BuildWithOptions failed: %v", err)
+	}
+
+	logPalette(t, pal)
+
+	// The orange cluster sits within tolerance of the complement and should be used
+	if d := hueDistance(formats.RGBAToHSLA(pal.Secondary).H, formats.RGBAToHSLA(orange).H); d > 2 {
+		t.Errorf("Expected secondary to use extracted orange cluster, hue off by %.1f°", d)
+	}
+}
+
+func TestBuildWithOptions_GrayscaleIgnoresScheme(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	profile := &processor.ColorProfile{
+		Mode: processor.Dark,
+		Colors: []processor.ColorCluster{
+			newCluster(s, color.RGBA{R: 128, G: 128, B: 128, A: 255}, 1.0),
+		},
+		HasColor:   false,
+		ColorCount: 1,
+	}
+
+	pal, err := g.BuildWithOptions(profile, palette.Options{Scheme: palette.SchemeTriadic})
+	if err != nil {
+		t.Fatalf("BuildWithOptions failed: %v", err)
+	}
+
+	for i, accent := range pal.Accents() {
+		if h := formats.RGBAToHSLA(accent); h.S > 0.01 {
+			t.Errorf("Expected neutral accent %d for grayscale profile, got saturation %.3f", i, h.S)
+		}
+	}
+}
+
+func TestDetectScheme(t *testing.T) {
+	s := settings.DefaultSettings()
+	g := palette.New(s)
+
+	hsl := func(h, sat, l float64) color.RGBA {
+		return formats.HSLAToRGBA(formats.NewHSL(h, sat, l))
+	}
+
+	testCases := []struct {
+		name     string
+		colors   []processor.ColorCluster
+		hasColor bool
+		expected palette.Scheme
+	}{
+		{
+			"Complementary accents",
+			[]processor.ColorCluster{
+				newCluster(s, hsl(0, 0, 0.1), 0.5),
+				newCluster(s, hsl(210, 0.7, 0.5), 0.3),
+				newCluster(s, hsl(30, 0.7, 0.5), 0.2),
+			},
+			true,
+			palette.SchemeComplementary,
+		},
+		{
+			"Triadic accents",
+			[]processor.ColorCluster{
+				newCluster(s, hsl(0, 0.7, 0.5), 0.4),
+				newCluster(s, hsl(120, 0.7, 0.5), 0.3),
+				newCluster(s, hsl(240, 0.7, 0.5), 0.3),
+			},
+			true,
+			palette.SchemeTriadic,
+		},
+		{
+			"Grayscale",
+			[]processor.ColorCluster{
+				newCluster(s, hsl(0, 0, 0.1), 0.6),
+				newCluster(s, hsl(0, 0, 0.9), 0.4),
+			},
+			false,
+			palette.SchemeExtracted,
+		},
+		{
+			"Scattered hues below confidence",
+			[]processor.ColorCluster{
+				newCluster(s, hsl(0, 0.7, 0.5), 0.2),
+				newCluster(s, hsl(72, 0.7, 0.5), 0.2),
+				newCluster(s, hsl(144, 0.7, 0.5), 0.2),
+				newCluster(s, hsl(216, 0.7, 0.5), 0.2),
+				newCluster(s, hsl(288, 0.7, 0.5), 0.2),
+			},
+			true,
+			palette.SchemeExtracted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := &processor.ColorProfile{
+				Mode:       processor.Dark,
+				Colors:     tc.colors,
+				HasColor:   tc.hasColor,
+				ColorCount: len(tc.colors),
+			}
+
+			scheme, confidence := g.DetectScheme(profile)
+			t.Logf("Detected %s (confidence %.2f)", scheme, confidence)
+
+			if scheme != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, scheme)
+			}
+		})
+	}
+}
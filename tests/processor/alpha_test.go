@@ -0,0 +1,211 @@
+package processor_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	themeerrors "github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// createHalfTranslucentImage fills the left half with a translucent color and
+// the right half with an opaque color. Set converts to the image's own
+// representation, so *image.RGBA stores the left half premultiplied.
+func createHalfTranslucentImage(img interface {
+	image.Image
+	Set(x, y int, c color.Color)
+}, translucent, opaque color.NRGBA) image.Image {
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if x < b.Dx()/2 {
+				img.Set(x, y, translucent)
+			} else {
+				img.Set(x, y, opaque)
+			}
+		}
+	}
+	return img
+}
+
+// findCluster returns the cluster whose color is within tolerance of target
+// on every channel.
+func findCluster(profile *processor.ColorProfile, target color.RGBA, tolerance int) (processor.ColorCluster, bool) {
+	near := func(a, b uint8) bool {
+		d := int(a) - int(b)
+		return d >= -tolerance && d <= tolerance
+	}
+	for _, c := range profile.Colors {
+		if near(c.RGBA.R, target.R) && near(c.RGBA.G, target.G) && near(c.RGBA.B, target.B) {
+			return c, true
+		}
+	}
+	return processor.ColorCluster{}, false
+}
+
+func logClusters(t *testing.T, profile *processor.ColorProfile) {
+	t.Helper()
+	for i, c := range profile.Colors {
+		t.Logf("  Color %d: RGBA(%d,%d,%d,%d) weight %.3f", i, c.RGBA.R, c.RGBA.G, c.RGBA.B, c.RGBA.A, c.Weight)
+	}
+}
+
+func TestProcessImage_TransparentPixelsSkipped(t *testing.T) {
+	// A logo occupying 30% of an otherwise fully transparent canvas
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	logo := color.RGBA{R: 200, G: 40, B: 40, A: 255}
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 30; x++ {
+			img.SetRGBA(x, y, logo)
+		}
+	}
+
+	p := processor.New(settings.DefaultSettings())
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("Profile: %d colors, %d samples", profile.ColorCount, profile.SampleCount)
+	logClusters(t, profile)
+
+	if profile.SampleCount != 3000 {
+		t.Errorf("Expected only the 3000 opaque pixels to be sampled, got %d", profile.SampleCount)
+	}
+
+	for _, c := range profile.Colors {
+		if c.Lightness < 0.05 {
+			t.Errorf("Unexpected black cluster RGBA(%d,%d,%d) from transparent pixels", c.RGBA.R, c.RGBA.G, c.RGBA.B)
+		}
+	}
+
+	if c, ok := findCluster(profile, logo, 8); !ok || c.Weight < 0.99 {
+		t.Errorf("Expected the logo color to carry the full weight")
+	}
+}
+
+func TestProcessImage_TranslucentStraightColor(t *testing.T) {
+	translucent := color.NRGBA{R: 200, G: 100, B: 50, A: 128}
+	opaque := color.NRGBA{R: 40, G: 60, B: 160, A: 255}
+
+	testCases := []struct {
+		name string
+		img  image.Image
+	}{
+		{"NRGBA", createHalfTranslucentImage(image.NewNRGBA(image.Rect(0, 0, 100, 100)), translucent, opaque)},
+		{"PremultipliedRGBA", createHalfTranslucentImage(image.NewRGBA(image.Rect(0, 0, 100, 100)), translucent, opaque)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := processor.New(settings.DefaultSettings())
+			profile, err := p.ProcessImage(tc.img)
+			if err != nil {
+				t.Fatalf("ProcessImage failed: %v", err)
+			}
+
+			logClusters(t, profile)
+
+			target := color.RGBA{R: translucent.R, G: translucent.G, B: translucent.B, A: 255}
+			c, ok := findCluster(profile, target, 8)
+			if !ok {
+				t.Fatalf("Expected translucent pixels to keep their straight color near RGBA(%d,%d,%d)", target.R, target.G, target.B)
+			}
+			if c.RGBA.A != 255 {
+				t.Errorf("Expected opaque cluster color, got alpha %d", c.RGBA.A)
+			}
+			if abs(c.Weight-0.5) > 0.01 {
+				t.Errorf("Expected translucent half to weigh 0.5, got %.3f", c.Weight)
+			}
+		})
+	}
+}
+
+func TestProcessImage_AlphaMatte(t *testing.T) {
+	translucent := color.NRGBA{R: 200, G: 100, B: 50, A: 128}
+	opaque := color.NRGBA{R: 40, G: 60, B: 160, A: 255}
+	img := createHalfTranslucentImage(image.NewNRGBA(image.Rect(0, 0, 100, 100)), translucent, opaque)
+
+	s := settings.DefaultSettings()
+	s.Processor.AlphaMatte = "#ffffff"
+	p := processor.New(s)
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	logClusters(t, profile)
+
+	// 50% over white: 200*128/255 + 255*127/255 ≈ 227, and so on
+	composited := color.RGBA{R: 227, G: 177, B: 152, A: 255}
+	if _, ok := findCluster(profile, composited, 8); !ok {
+		t.Errorf("Expected translucent pixels composited over white near RGBA(%d,%d,%d)", composited.R, composited.G, composited.B)
+	}
+
+	solid := color.RGBA{R: opaque.R, G: opaque.G, B: opaque.B, A: 255}
+	if _, ok := findCluster(profile, solid, 8); !ok {
+		t.Errorf("Expected opaque pixels to be unaffected by the matte")
+	}
+}
+
+func TestProcessImage_MinAlpha(t *testing.T) {
+	translucent := color.NRGBA{R: 200, G: 100, B: 50, A: 128}
+	opaque := color.NRGBA{R: 40, G: 60, B: 160, A: 255}
+	img := createHalfTranslucentImage(image.NewNRGBA(image.Rect(0, 0, 100, 100)), translucent, opaque)
+
+	s := settings.DefaultSettings()
+	s.Processor.MinAlpha = 200
+	p := processor.New(s)
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("Profile: %d colors, %d samples", profile.ColorCount, profile.SampleCount)
+	logClusters(t, profile)
+
+	if profile.SampleCount != 5000 {
+		t.Errorf("Expected pixels below min_alpha to be skipped, got %d samples", profile.SampleCount)
+	}
+	if profile.ColorCount != 1 {
+		t.Errorf("Expected only the opaque color, got %d colors", profile.ColorCount)
+	}
+}
+
+func TestProcessImage_FullyTransparent(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 50, 50))
+
+	p := processor.New(settings.DefaultSettings())
+	_, err := p.ProcessImage(img)
+	if err == nil {
+		t.Fatal("Expected an error for a fully transparent image")
+	}
+
+	t.Logf("Fully transparent image: %v", err)
+}
+
+func TestProcessImage_InvalidAlphaMatte(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Processor.AlphaMatte = "#nothex"
+	p := processor.New(s)
+
+	_, err := p.ProcessImage(createRegionRGBA(100, 50))
+	if err == nil {
+		t.Fatal("Expected an error for an invalid alpha matte")
+	}
+
+	t.Logf("Invalid matte: %v", err)
+
+	var extractionErr *themeerrors.ExtractionError
+	if !errors.As(err, &extractionErr) {
+		t.Fatalf("Expected *ExtractionError, got %T", err)
+	}
+	if extractionErr.Stage != "alpha" {
+		t.Errorf("Expected stage alpha, got %s", extractionErr.Stage)
+	}
+}
@@ -0,0 +1,187 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"sync/atomic"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// createGradientImage returns an image large enough to exercise concurrent
+// extraction when width*height exceeds 100,000 pixels.
+func createGradientImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 255 / width), G: uint8(y * 255 / height), B: 120, A: 255})
+		}
+	}
+	return img
+}
+
+func TestProcessImageContext_Progress(t *testing.T) {
+	testCases := []struct {
+		name   string
+		width  int
+		height int
+	}{
+		{"Sequential", 100, 80},
+		{"Concurrent", 600, 400},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := processor.New(settings.DefaultSettings())
+			img := createGradientImage(tc.width, tc.height)
+
+			calls, last, total := 0, 0, 0
+			opts := processor.ProcessOptions{
+				Progress: func(processed, n int) {
+					calls++
+					if processed != last+1 {
+						t.Errorf("Progress jumped from %d to %d", last, processed)
+					}
+					last, total = processed, n
+				},
+			}
+
+			profile, err := p.ProcessImageContext(context.Background(), img, opts)
+			if err != nil {
+				t.Fatalf("ProcessImageContext failed: %v", err)
+			}
+
+			t.Logf("%dx%d: %d progress calls, final %d/%d, %d samples", tc.width, tc.height, calls, last, total, profile.SampleCount)
+
+			if last != total || total != tc.height {
+				t.Errorf("Expected progress to end at %d/%d, got %d/%d", tc.height, tc.height, last, total)
+			}
+		})
+	}
+}
+
+func TestProcessImageContext_Cancelled(t *testing.T) {
+	p := processor.New(settings.DefaultSettings())
+	img := createGradientImage(600, 400)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	profile, err := p.ProcessImageContext(ctx, img, processor.ProcessOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if profile != nil {
+		t.Errorf("Expected no profile for a cancelled context")
+	}
+}
+
+func TestProcessImageContext_CancelDuringExtraction(t *testing.T) {
+	testCases := []struct {
+		name   string
+		width  int
+		height int
+	}{
+		{"Sequential", 100, 300},
+		{"Concurrent", 600, 400},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := processor.New(settings.DefaultSettings())
+			img := createGradientImage(tc.width, tc.height)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			processed, total := 0, 0
+			opts := processor.ProcessOptions{
+				Progress: func(n, rows int) {
+					processed, total = n, rows
+					if n == 10 {
+						cancel()
+					}
+				},
+			}
+
+			_, err := p.ProcessImageContext(ctx, img, opts)
+			t.Logf("Stopped after %d of %d rows: %v", processed, total, err)
+
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context.Canceled, got %v", err)
+			}
+			if processed >= total {
+				t.Errorf("Expected extraction to stop early, processed %d of %d rows", processed, total)
+			}
+		})
+	}
+}
+
+func TestProcessImage_MatchesContext(t *testing.T) {
+	p := processor.New(settings.DefaultSettings())
+	img := createGradientImage(120, 90)
+
+	plain, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+	withContext, err := p.ProcessImageContext(context.Background(), img, processor.ProcessOptions{})
+	if err != nil {
+		t.Fatalf("ProcessImageContext failed: %v", err)
+	}
+
+	if plain.SampleCount != withContext.SampleCount || plain.UniqueColors != withContext.UniqueColors ||
+		len(plain.Colors) != len(withContext.Colors) {
+		t.Errorf("Expected identical profiles, got %d/%d samples and %d/%d clusters",
+			plain.SampleCount, withContext.SampleCount, len(plain.Colors), len(withContext.Colors))
+	}
+}
+
+// cancellingImage cancels its context on the first pixel read and counts
+// every read, so a test can see how much work continues after cancellation.
+type cancellingImage struct {
+	image.Image
+	cancel context.CancelFunc
+	reads  *atomic.Int64
+}
+
+func (c cancellingImage) At(x, y int) color.Color {
+	if c.reads.Add(1) == 1 {
+		c.cancel()
+	}
+	return c.Image.At(x, y)
+}
+
+func TestProcessImageContext_CancelledDuringSaliency(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Processor.SpatialWeighting = string(processor.SaliencyWeighting)
+	s.Processor.SaliencyGrid = 16
+	p := processor.New(s)
+
+	// 4096x4096 in 16x16 cells of 256 pixels samples every 32nd pixel, so a
+	// full saliency pass reads 128x128 pixels before extraction starts
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	img := cancellingImage{
+		Image:  insetImage{bounds: image.Rect(0, 0, 4096, 4096), background: navy, inset: image.Rect(1024, 1024, 3072, 3072), fill: orange},
+		cancel: cancel,
+		reads:  &atomic.Int64{},
+	}
+
+	profile, err := p.ProcessImageContext(ctx, img, processor.ProcessOptions{})
+	t.Logf("Pixel reads with cancellation on the first read: %d", img.reads.Load())
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if profile != nil {
+		t.Errorf("Expected no profile for a cancelled context")
+	}
+	if reads := img.reads.Load(); reads > 128 {
+		t.Errorf("Expected saliency weighting to stop within one row of 128 reads, got %d", reads)
+	}
+}
@@ -0,0 +1,136 @@
+package processor_test
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// createTexturedImage returns an image with fine per-pixel variation so that
+// sampling a different grid of rows produces a different histogram.
+func createTexturedImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(40 + (x*3+y*5)%160),
+				G: uint8(60 + (y*7)%120),
+				B: uint8(90 + (x*y)%100),
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func TestProcessImage_DeterministicAcrossWorkers(t *testing.T) {
+	// Over the default sample budget with an odd height, so pixels are
+	// sampled from 2x2 cells and an even split of image rows among workers
+	// would shift the sampling grid
+	img := createTexturedImage(2100, 1001)
+	p := processor.New(settings.DefaultSettings())
+
+	previous := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(previous)
+
+	var reference *processor.ColorProfile
+	for _, procs := range []int{1, 2, 3, 7} {
+		runtime.GOMAXPROCS(procs)
+
+		profile, err := p.ProcessImage(img)
+		if err != nil {
+			t.Fatalf("ProcessImage with GOMAXPROCS=%d failed: %v", procs, err)
+		}
+
+		t.Logf("GOMAXPROCS=%d: %d colors, %d samples, fingerprint %s", procs, profile.ColorCount, profile.SampleCount, profile.Fingerprint())
+
+		if reference == nil {
+			reference = profile
+			continue
+		}
+		if !reflect.DeepEqual(profile, reference) {
+			t.Errorf("Profile with GOMAXPROCS=%d differs from GOMAXPROCS=1", procs)
+		}
+		if profile.Fingerprint() != reference.Fingerprint() {
+			t.Errorf("Fingerprint with GOMAXPROCS=%d differs: %s vs %s", procs, profile.Fingerprint(), reference.Fingerprint())
+		}
+	}
+}
+
+func TestProcessImage_DeterministicTies(t *testing.T) {
+	// Four colors of exactly equal weight
+	img := createTestImage(200, 200, []color.RGBA{navy, red, green, orange})
+
+	testCases := []struct {
+		name      string
+		extractor processor.ExtractionMethod
+		algorithm processor.ClusteringAlgorithm
+	}{
+		{"Greedy", processor.Histogram, processor.Greedy},
+		{"KMeans", processor.Histogram, processor.KMeans},
+		{"MedianCut", processor.MedianCut, processor.Greedy},
+		{"Octree", processor.Octree, processor.Greedy},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := settings.DefaultSettings()
+			s.Processor.Extractor = string(tc.extractor)
+			s.Processor.ClusteringAlgorithm = string(tc.algorithm)
+			p := processor.New(s)
+
+			var fingerprint string
+			for run := 0; run < 20; run++ {
+				profile, err := p.ProcessImage(img)
+				if err != nil {
+					t.Fatalf("ProcessImage failed: %v", err)
+				}
+
+				if run == 0 {
+					fingerprint = profile.Fingerprint()
+					logClusters(t, profile)
+					continue
+				}
+				if profile.Fingerprint() != fingerprint {
+					t.Fatalf("Run %d produced fingerprint %s, expected %s", run, profile.Fingerprint(), fingerprint)
+				}
+			}
+
+			t.Logf("20 runs with fingerprint %s", fingerprint)
+		})
+	}
+}
+
+func TestColorProfile_Fingerprint(t *testing.T) {
+	img := createRegionRGBA(200, 100)
+
+	profile, err := processor.New(settings.DefaultSettings()).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	fingerprint := profile.Fingerprint()
+	t.Logf("Fingerprint: %s", fingerprint)
+
+	if len(fingerprint) != 64 {
+		t.Errorf("Expected a 64 character SHA-256 hex digest, got %d characters", len(fingerprint))
+	}
+
+	changed := *profile
+	changed.Colors = append([]processor.ColorCluster(nil), profile.Colors...)
+	changed.Colors[0].Weight += 0.01
+	if changed.Fingerprint() == fingerprint {
+		t.Error("Expected a weight change to change the fingerprint")
+	}
+
+	// Differences below the rounding precision do not
+	changed.Colors[0].Weight = profile.Colors[0].Weight + 1e-12
+	if changed.Fingerprint() != fingerprint {
+		t.Error("Expected floating point noise to leave the fingerprint unchanged")
+	}
+}
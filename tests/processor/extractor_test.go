@@ -0,0 +1,161 @@
+package processor_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// twoGroupHistogram builds a histogram of reds and blues. The reds average
+// to (200, 40, 40) and the blues to (40, 60, 200).
+func twoGroupHistogram() map[color.RGBA]uint64 {
+	return map[color.RGBA]uint64{
+		{R: 192, G: 40, B: 40, A: 255}: 100,
+		{R: 208, G: 40, B: 40, A: 255}: 100,
+		{R: 200, G: 32, B: 40, A: 255}: 50,
+		{R: 200, G: 48, B: 40, A: 255}: 50,
+		{R: 40, G: 56, B: 200, A: 255}: 150,
+		{R: 40, G: 64, B: 200, A: 255}: 150,
+	}
+}
+
+func histogramTotal(h map[color.RGBA]uint64) uint64 {
+	var total uint64
+	for _, count := range h {
+		total += count
+	}
+	return total
+}
+
+func TestExtractors_Reduce(t *testing.T) {
+	testCases := []struct {
+		name      string
+		extractor processor.Extractor
+	}{
+		{"median-cut", processor.NewMedianCutExtractor(2)},
+		{"octree", processor.NewOctreeExtractor(2)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			histogram := twoGroupHistogram()
+			reduced := tc.extractor.Reduce(histogram)
+
+			for c, count := range reduced {
+				t.Logf("  %s: %d", formats.ToHex(c), count)
+			}
+
+			if len(reduced) != 2 {
+				t.Fatalf("Expected 2 colors, got %d", len(reduced))
+			}
+			if total := histogramTotal(reduced); total != histogramTotal(histogram) {
+				t.Errorf("Expected total count %d preserved, got %d", histogramTotal(histogram), total)
+			}
+
+			red := color.RGBA{R: 200, G: 40, B: 40, A: 255}
+			blue := color.RGBA{R: 40, G: 60, B: 200, A: 255}
+			if reduced[red] != 300 {
+				t.Errorf("Expected red average %s with count 300, got %d", formats.ToHex(red), reduced[red])
+			}
+			if reduced[blue] != 300 {
+				t.Errorf("Expected blue average %s with count 300, got %d", formats.ToHex(blue), reduced[blue])
+			}
+		})
+	}
+}
+
+func TestExtractors_ReduceBelowTarget(t *testing.T) {
+	histogram := twoGroupHistogram()
+
+	extractors := map[string]processor.Extractor{
+		"histogram":  processor.NewHistogramExtractor(5),
+		"median-cut": processor.NewMedianCutExtractor(64),
+		"octree":     processor.NewOctreeExtractor(64),
+	}
+
+	for name, e := range extractors {
+		reduced := e.Reduce(histogram)
+		t.Logf("%s: %d colors from %d", name, len(reduced), len(histogram))
+		if total := histogramTotal(reduced); total != histogramTotal(histogram) {
+			t.Errorf("%s: expected total count %d, got %d", name, histogramTotal(histogram), total)
+		}
+		if len(reduced) > len(histogram) {
+			t.Errorf("%s: reduce added colors", name)
+		}
+	}
+}
+
+func TestProcessImage_Extractors(t *testing.T) {
+	// Horizontal gradient from dark blue to orange over a dark band
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			if y < 60 {
+				img.Set(x, y, color.RGBA{R: 20, G: 20, B: 30, A: 255})
+				continue
+			}
+			f := float64(x) / 199
+			img.Set(x, y, color.RGBA{
+				R: uint8(30 + f*210),
+				G: uint8(50 + f*80),
+				B: uint8(160 - f*130),
+				A: 255,
+			})
+		}
+	}
+
+	for _, method := range []processor.ExtractionMethod{processor.Histogram, processor.MedianCut, processor.Octree} {
+		t.Run(string(method), func(t *testing.T) {
+			s := settings.DefaultSettings()
+			s.Processor.Extractor = string(method)
+			s.Processor.ExtractorColors = 16
+
+			profile, err := processor.New(s).ProcessImage(img)
+			if err != nil {
+				t.Fatalf("ProcessImage failed: %v", err)
+			}
+
+			t.Logf("%s: %d clusters from %d unique colors", method, profile.ColorCount, profile.UniqueColors)
+			for i, c := range profile.Colors {
+				t.Logf("  Cluster %d: %s weight %.3f", i, formats.ToHex(c.RGBA), c.Weight)
+			}
+
+			if profile.Mode != processor.Dark {
+				t.Errorf("Expected Dark mode, got %s", profile.Mode)
+			}
+			dominant := profile.Colors[0]
+			if dominant.Weight < 0.5 || !dominant.IsDark {
+				t.Errorf("Expected the dark band to dominate, got %s at %.3f",
+					formats.ToHex(dominant.RGBA), dominant.Weight)
+			}
+			if !profile.HasColor {
+				t.Error("Expected gradient colors to be significant")
+			}
+		})
+	}
+}
+
+func TestProcessImage_UnknownExtractorFallsBackToHistogram(t *testing.T) {
+	img := createRegionImage(40, 40)
+
+	s := settings.DefaultSettings()
+	histogram, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	s.Processor.Extractor = "unknown"
+	fallback, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	if fallback.UniqueColors != histogram.UniqueColors || len(fallback.Colors) != len(histogram.Colors) {
+		t.Errorf("Expected histogram results, got %d unique and %d clusters",
+			fallback.UniqueColors, len(fallback.Colors))
+	}
+}
@@ -0,0 +1,180 @@
+package processor_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// createRegionImage fills the left half with blue shades and the right half
+// with orange shades. Each half is 50% of its first shade, 30% its second, and
+// 20% its third.
+func createRegionImage(width, height int) image.Image {
+	blues := []color.RGBA{{40, 80, 200, 255}, {60, 100, 220, 255}, {20, 60, 170, 255}}
+	oranges := []color.RGBA{{230, 120, 40, 255}, {250, 150, 60, 255}, {200, 100, 20, 255}}
+
+	pick := func(shades []color.RGBA, i int) color.RGBA {
+		switch {
+		case i%10 < 5:
+			return shades[0]
+		case i%10 < 8:
+			return shades[1]
+		default:
+			return shades[2]
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*width + x
+			if x < width/2 {
+				img.Set(x, y, pick(blues, i))
+			} else {
+				img.Set(x, y, pick(oranges, i))
+			}
+		}
+	}
+	return img
+}
+
+func TestProcessImage_KMeansCentroids(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Processor.ClusteringAlgorithm = string(processor.KMeans)
+	s.Processor.KMeansClusters = 2
+	p := processor.New(s)
+
+	img := createRegionImage(40, 40)
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("K-means (%s, k=%d) clusters:", s.Processor.KMeansColorSpace, s.Processor.KMeansClusters)
+	for i, c := range profile.Colors {
+		t.Logf("  Cluster %d: %s weight %.3f", i, formats.ToHex(c.RGBA), c.Weight)
+	}
+
+	if len(profile.Colors) != 2 {
+		t.Fatalf("Expected 2 clusters, got %d", len(profile.Colors))
+	}
+
+	var total float64
+	for _, c := range profile.Colors {
+		total += c.Weight
+	}
+	if abs(total-1.0) > 0.001 {
+		t.Errorf("Expected cluster weights to sum to 1.0, got %.4f", total)
+	}
+
+	// Centroids sit between the shades of their region, not on the most
+	// frequent quantized shade
+	greedy := settings.DefaultSettings()
+	greedyProfile, err := processor.New(greedy).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	representatives := make(map[color.RGBA]bool)
+	for _, c := range greedyProfile.Colors {
+		t.Logf("  Greedy: %s weight %.3f", formats.ToHex(c.RGBA), c.Weight)
+		representatives[c.RGBA] = true
+	}
+
+	for _, c := range profile.Colors {
+		if representatives[c.RGBA] {
+			t.Errorf("Expected centroid %s to differ from greedy representatives", formats.ToHex(c.RGBA))
+		}
+	}
+
+	blue, orange := profile.Colors[0], profile.Colors[1]
+	if blue.B < blue.R {
+		blue, orange = orange, blue
+	}
+	if blue.B < 170 || blue.B > 220 || blue.R > 60 {
+		t.Errorf("Blue centroid %s outside its region's shades", formats.ToHex(blue.RGBA))
+	}
+	if orange.R < 200 || orange.R > 250 || orange.B > 60 {
+		t.Errorf("Orange centroid %s outside its region's shades", formats.ToHex(orange.RGBA))
+	}
+}
+
+func TestProcessImage_KMeansReproducible(t *testing.T) {
+	img := createRegionImage(64, 64)
+
+	for _, space := range []string{"lab", "oklab"} {
+		t.Run(space, func(t *testing.T) {
+			s := settings.DefaultSettings()
+			s.Processor.ClusteringAlgorithm = string(processor.KMeans)
+			s.Processor.KMeansColorSpace = space
+
+			first, err := processor.New(s).ProcessImage(img)
+			if err != nil {
+				t.Fatalf("ProcessImage failed: %v", err)
+			}
+
+			for run := 0; run < 5; run++ {
+				next, err := processor.New(s).ProcessImage(img)
+				if err != nil {
+					t.Fatalf("ProcessImage failed: %v", err)
+				}
+				if len(next.Colors) != len(first.Colors) {
+					t.Fatalf("Run %d: expected %d clusters, got %d", run, len(first.Colors), len(next.Colors))
+				}
+				for i := range next.Colors {
+					if next.Colors[i].RGBA != first.Colors[i].RGBA {
+						t.Errorf("Run %d cluster %d: %s != %s", run, i,
+							formats.ToHex(next.Colors[i].RGBA), formats.ToHex(first.Colors[i].RGBA))
+					}
+				}
+			}
+			t.Logf("%s: %d clusters stable across runs", space, len(first.Colors))
+		})
+	}
+}
+
+func TestProcessImage_KMeansWithoutIterations(t *testing.T) {
+	img := createRegionImage(40, 40)
+
+	for _, iterations := range []int{0, -1} {
+		s := settings.DefaultSettings()
+		s.Processor.ClusteringAlgorithm = string(processor.KMeans)
+		s.Processor.KMeansMaxIterations = iterations
+
+		profile, err := processor.New(s).ProcessImage(img)
+		if err != nil {
+			t.Fatalf("ProcessImage with %d iterations failed: %v", iterations, err)
+		}
+
+		t.Logf("%d iterations: %d clusters from the seeded centers", iterations, len(profile.Colors))
+
+		if len(profile.Colors) == 0 {
+			t.Errorf("Expected clusters with %d iterations", iterations)
+		}
+	}
+}
+
+func TestProcessImage_UnknownClusteringFallsBackToGreedy(t *testing.T) {
+	img := createRegionImage(40, 40)
+
+	s := settings.DefaultSettings()
+	greedy, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	s.Processor.ClusteringAlgorithm = "unknown"
+	fallback, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	if len(fallback.Colors) != len(greedy.Colors) {
+		t.Errorf("Expected %d greedy clusters, got %d", len(greedy.Colors), len(fallback.Colors))
+	}
+}
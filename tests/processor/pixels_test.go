@@ -0,0 +1,142 @@
+package processor_test
+
+import (
+	"cmp"
+	"image"
+	"image/color"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// opaqueImage hides the concrete image type so processing falls back to
+// generic per-pixel color conversion.
+type opaqueImage struct {
+	image.Image
+}
+
+// regionIndex splits the width into regions covering 40%, 30%, 20%, and 10%
+// of the image so every region has a distinct weight.
+func regionIndex(x, width int) int {
+	switch {
+	case x < width*4/10:
+		return 0
+	case x < width*7/10:
+		return 1
+	case x < width*9/10:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func createRegionRGBA(width, height int) image.Image {
+	colors := []color.RGBA{
+		{R: 30, G: 40, B: 70, A: 255},
+		{R: 200, G: 120, B: 60, A: 255},
+		{R: 60, G: 140, B: 90, A: 255},
+		{R: 220, G: 210, B: 190, A: 255},
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, colors[regionIndex(x, width)])
+		}
+	}
+	return img
+}
+
+func createRegionNRGBA(width, height int) image.Image {
+	colors := []color.NRGBA{
+		{R: 30, G: 40, B: 70, A: 255},
+		{R: 200, G: 120, B: 60, A: 255},
+		{R: 60, G: 140, B: 90, A: 200},
+		{R: 220, G: 210, B: 190, A: 255},
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, colors[regionIndex(x, width)])
+		}
+	}
+	return img
+}
+
+func createRegionYCbCr(width, height int, ratio image.YCbCrSubsampleRatio) image.Image {
+	colors := []color.YCbCr{
+		{Y: 41, Cb: 147, Cr: 121},
+		{Y: 135, Cb: 91, Cr: 171},
+		{Y: 117, Cb: 113, Cr: 101},
+		{Y: 207, Cb: 117, Cr: 137},
+	}
+	img := image.NewYCbCr(image.Rect(0, 0, width, height), ratio)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := colors[regionIndex(x, width)]
+			img.Y[img.YOffset(x, y)] = c.Y
+			ci := img.COffset(x, y)
+			img.Cb[ci] = c.Cb
+			img.Cr[ci] = c.Cr
+		}
+	}
+	return img
+}
+
+// sortedByColor returns a copy of the profile with its clusters ordered by
+// color, so profiles can be compared regardless of the order of ties.
+func sortedByColor(profile *processor.ColorProfile) *processor.ColorProfile {
+	sorted := *profile
+	sorted.Colors = slices.Clone(profile.Colors)
+	slices.SortFunc(sorted.Colors, func(a, b processor.ColorCluster) int {
+		return cmp.Or(
+			cmp.Compare(a.RGBA.R, b.RGBA.R),
+			cmp.Compare(a.RGBA.G, b.RGBA.G),
+			cmp.Compare(a.RGBA.B, b.RGBA.B),
+			cmp.Compare(a.RGBA.A, b.RGBA.A),
+		)
+	})
+	return &sorted
+}
+
+func TestProcessImage_FastPathsMatchGeneric(t *testing.T) {
+	testCases := []struct {
+		name string
+		img  image.Image
+	}{
+		{"RGBA", createRegionRGBA(200, 100)},
+		{"NRGBA", createRegionNRGBA(200, 100)},
+		{"YCbCr444", createRegionYCbCr(200, 100, image.YCbCrSubsampleRatio444)},
+		{"YCbCr420", createRegionYCbCr(200, 100, image.YCbCrSubsampleRatio420)},
+		{"SubImage", createRegionRGBA(200, 100).(*image.RGBA).SubImage(image.Rect(20, 10, 180, 90))},
+		{"ConcurrentYCbCr", createRegionYCbCr(600, 400, image.YCbCrSubsampleRatio420)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := processor.New(settings.DefaultSettings())
+
+			fast, err := p.ProcessImage(tc.img)
+			if err != nil {
+				t.Fatalf("ProcessImage failed: %v", err)
+			}
+
+			generic, err := p.ProcessImage(opaqueImage{tc.img})
+			if err != nil {
+				t.Fatalf("ProcessImage failed for generic image: %v", err)
+			}
+
+			t.Logf("%T: %d colors, %d unique, %d samples", tc.img, fast.ColorCount, fast.UniqueColors, fast.SampleCount)
+			for i, c := range fast.Colors {
+				t.Logf("  Color %d: RGBA(%d,%d,%d,%d) weight %.3f", i, c.RGBA.R, c.RGBA.G, c.RGBA.B, c.RGBA.A, c.Weight)
+			}
+
+			// Clusters of equal weight may be reported in either order
+			if !reflect.DeepEqual(sortedByColor(fast), sortedByColor(generic)) {
+				t.Errorf("Fast path profile differs from generic conversion:\nfast:    %+v\ngeneric: %+v", fast, generic)
+			}
+		})
+	}
+}
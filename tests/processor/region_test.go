@@ -0,0 +1,227 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	themeerrors "github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+var green = color.RGBA{R: 60, G: 160, B: 80, A: 255}
+
+// createWideWallpaper returns a 2000x1000 image whose outer quarters are
+// green and whose middle half is navy under a 40 pixel red top strip. Filled
+// onto a square screen, only the middle half is visible.
+func createWideWallpaper() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 2000, 1000))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: green}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(500, 0, 1500, 1000), &image.Uniform{C: navy}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(500, 0, 1500, 40), &image.Uniform{C: red}, image.Point{}, draw.Src)
+	return img
+}
+
+func TestProcessRegion(t *testing.T) {
+	img := createInsetImage(200, 100, navy, image.Rect(0, 0, 100, 100), red)
+
+	testCases := []struct {
+		name string
+		img  image.Image
+	}{
+		{"SubImage", img},
+		{"Generic", opaqueImage{img}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := processor.New(settings.DefaultSettings())
+
+			profile, err := p.ProcessRegion(context.Background(), tc.img, image.Rect(0, 0, 100, 100), processor.ProcessOptions{})
+			if err != nil {
+				t.Fatalf("ProcessRegion failed: %v", err)
+			}
+
+			t.Logf("Left half: %d colors, %d samples", profile.ColorCount, profile.SampleCount)
+			logClusters(t, profile)
+
+			if profile.SampleCount != 10000 {
+				t.Errorf("Expected 10000 samples from the region, got %d", profile.SampleCount)
+			}
+			if profile.ColorCount != 1 {
+				t.Errorf("Expected only the region's color, got %d colors", profile.ColorCount)
+			}
+			if _, ok := findCluster(profile, red, 8); !ok {
+				t.Errorf("Expected the region's red to be extracted")
+			}
+		})
+	}
+}
+
+func TestProcessRegion_ClipsToBounds(t *testing.T) {
+	img := createInsetImage(200, 100, navy, image.Rect(150, 0, 200, 100), red)
+	p := processor.New(settings.DefaultSettings())
+
+	profile, err := p.ProcessRegion(context.Background(), img, image.Rect(150, -50, 400, 200), processor.ProcessOptions{})
+	if err != nil {
+		t.Fatalf("ProcessRegion failed: %v", err)
+	}
+
+	t.Logf("Clipped region: %d samples", profile.SampleCount)
+
+	if profile.SampleCount != 5000 {
+		t.Errorf("Expected the region clipped to 50x100, got %d samples", profile.SampleCount)
+	}
+}
+
+func TestProcessRegion_OutsideImage(t *testing.T) {
+	img := createInsetImage(200, 100, navy, image.Rect(0, 0, 100, 100), red)
+	p := processor.New(settings.DefaultSettings())
+
+	_, err := p.ProcessRegion(context.Background(), img, image.Rect(300, 300, 400, 400), processor.ProcessOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for a region outside the image")
+	}
+
+	t.Logf("Outside region: %v", err)
+
+	var extractionErr *themeerrors.ExtractionError
+	if !errors.As(err, &extractionErr) {
+		t.Errorf("Expected *ExtractionError, got %T", err)
+	}
+	if !errors.Is(err, themeerrors.ErrEmptyImage) {
+		t.Errorf("Expected error to wrap ErrEmptyImage")
+	}
+}
+
+func TestScreen_ImageRect(t *testing.T) {
+	bounds := image.Rect(0, 0, 2000, 1000)
+	topBar := image.Rect(0, 0, 1000, 32)
+
+	testCases := []struct {
+		fit      processor.WallpaperFit
+		expected image.Rectangle
+	}{
+		{processor.Fill, image.Rect(500, 0, 1500, 32)},
+		{processor.Fit, image.Rectangle{}},
+		{processor.Stretch, image.Rect(0, 0, 2000, 32)},
+		{processor.Centered, image.Rect(500, 0, 1500, 32)},
+		{"unknown", image.Rect(500, 0, 1500, 32)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.fit), func(t *testing.T) {
+			screen := processor.Screen{Width: 1000, Height: 1000, Fit: tc.fit}
+			rect := screen.ImageRect(bounds, topBar)
+
+			t.Logf("%s: screen %v -> image %v", tc.fit, topBar, rect)
+
+			if rect != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, rect)
+			}
+		})
+	}
+}
+
+func TestProcessRegions_Fill(t *testing.T) {
+	p := processor.New(settings.DefaultSettings())
+	screen := processor.Screen{Width: 1000, Height: 1000, Fit: processor.Fill}
+
+	profiles, err := p.ProcessRegions(context.Background(), createWideWallpaper(), screen)
+	if err != nil {
+		t.Fatalf("ProcessRegions failed: %v", err)
+	}
+
+	for _, region := range processor.ScreenRegions {
+		profile, ok := profiles[region]
+		if !ok {
+			t.Fatalf("Missing profile for region %s", region)
+		}
+		t.Logf("Region %s: %d colors, %d samples", region, profile.ColorCount, profile.SampleCount)
+		logClusters(t, profile)
+
+		if _, ok := findCluster(profile, green, 8); ok {
+			t.Errorf("Region %s includes green from the cropped sides", region)
+		}
+	}
+
+	top := profiles[processor.TopRegion]
+	if c, ok := findCluster(top, red, 8); !ok || c.Weight < 0.99 {
+		t.Errorf("Expected the top bar region to be the red strip")
+	}
+
+	if _, ok := findCluster(profiles[processor.BottomRegion], red, 8); ok {
+		t.Errorf("Expected the bottom bar region to exclude the top strip")
+	}
+}
+
+func TestProcessRegions_Stretch(t *testing.T) {
+	p := processor.New(settings.DefaultSettings())
+	screen := processor.Screen{Width: 1000, Height: 1000, Fit: processor.Stretch}
+
+	profiles, err := p.ProcessRegions(context.Background(), createWideWallpaper(), screen, processor.LeftRegion)
+	if err != nil {
+		t.Fatalf("ProcessRegions failed: %v", err)
+	}
+
+	if len(profiles) != 1 {
+		t.Fatalf("Expected only the requested region, got %d", len(profiles))
+	}
+
+	left := profiles[processor.LeftRegion]
+	logClusters(t, left)
+
+	if c, ok := findCluster(left, green, 8); !ok || abs(c.Weight-0.5) > 0.01 {
+		t.Errorf("Expected the stretched left half to be half green")
+	}
+}
+
+func TestProcessRegions_FitOmitsLetterbox(t *testing.T) {
+	p := processor.New(settings.DefaultSettings())
+	screen := processor.Screen{Width: 1000, Height: 1000, Fit: processor.Fit}
+
+	profiles, err := p.ProcessRegions(context.Background(), createWideWallpaper(), screen)
+	if err != nil {
+		t.Fatalf("ProcessRegions failed: %v", err)
+	}
+
+	t.Logf("Fit regions: %d of %d", len(profiles), len(processor.ScreenRegions))
+
+	for _, region := range []processor.ScreenRegion{processor.TopRegion, processor.BottomRegion} {
+		if _, ok := profiles[region]; ok {
+			t.Errorf("Expected region %s inside the letterbox to be omitted", region)
+		}
+	}
+	for _, region := range []processor.ScreenRegion{processor.CenterRegion, processor.LeftRegion, processor.RightRegion} {
+		if _, ok := profiles[region]; !ok {
+			t.Errorf("Expected region %s to be processed", region)
+		}
+	}
+}
+
+func TestProcessRegions_Errors(t *testing.T) {
+	p := processor.New(settings.DefaultSettings())
+	img := createWideWallpaper()
+
+	if _, err := p.ProcessRegions(context.Background(), img, processor.Screen{Width: 0, Height: 1080}); err == nil {
+		t.Error("Expected an error for an invalid screen resolution")
+	} else {
+		t.Logf("Invalid screen: %v", err)
+	}
+
+	screen := processor.Screen{Width: 1920, Height: 1080}
+	empty := image.NewRGBA(image.Rectangle{})
+	if _, err := p.ProcessRegions(context.Background(), empty, screen); !errors.Is(err, themeerrors.ErrEmptyImage) {
+		t.Errorf("Expected ErrEmptyImage for an empty image, got %v", err)
+	}
+
+	if _, err := p.ProcessRegions(context.Background(), img, screen, "sidebar"); err == nil {
+		t.Error("Expected an error for an unknown region")
+	} else {
+		t.Logf("Unknown region: %v", err)
+	}
+}
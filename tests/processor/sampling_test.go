@@ -0,0 +1,127 @@
+package processor_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// createStripedImage alternates red and blue columns, a pattern a fixed
+// two-pixel stride would see as solid red.
+func createStripedImage(width, height int) image.Image {
+	blue := color.RGBA{R: 40, G: 60, B: 200, A: 255}
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x%2 == 0 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func TestProcessImage_SampleBudget(t *testing.T) {
+	testCases := []struct {
+		name       string
+		width      int
+		height     int
+		maxSamples int
+		expected   int // Exact sample count, or 0 to only check the budget
+	}{
+		{"WithinBudget", 200, 100, 50000, 20000},
+		{"NoLimit", 400, 300, 0, 120000},
+		{"ExactFit", 400, 300, 30000, 30000},
+		{"UnevenCells", 1001, 777, 40000, 0},
+		{"LargeImage", 3000, 2000, 100000, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := settings.DefaultSettings()
+			s.Processor.MaxSamples = tc.maxSamples
+			p := processor.New(s)
+
+			profile, err := p.ProcessImage(createTexturedImage(tc.width, tc.height))
+			if err != nil {
+				t.Fatalf("ProcessImage failed: %v", err)
+			}
+
+			t.Logf("%dx%d with budget %d: %d samples", tc.width, tc.height, tc.maxSamples, profile.SampleCount)
+
+			if tc.expected > 0 && profile.SampleCount != tc.expected {
+				t.Errorf("Expected %d samples, got %d", tc.expected, profile.SampleCount)
+			}
+			if tc.maxSamples > 0 && profile.SampleCount > tc.maxSamples {
+				t.Errorf("Sample count %d exceeds budget %d", profile.SampleCount, tc.maxSamples)
+			}
+			if tc.expected == 0 && profile.SampleCount < tc.maxSamples/2 {
+				t.Errorf("Sample count %d uses less than half the budget %d", profile.SampleCount, tc.maxSamples)
+			}
+		})
+	}
+}
+
+func TestProcessImage_JitteredSamplingAvoidsAliasing(t *testing.T) {
+	// 1200x800 with a budget of a quarter of the pixels samples 2x2 cells
+	img := createStripedImage(1200, 800)
+
+	s := settings.DefaultSettings()
+	s.Processor.MaxSamples = 240000
+	p := processor.New(s)
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("Striped image: %d samples", profile.SampleCount)
+	logClusters(t, profile)
+
+	if profile.ColorCount != 2 {
+		t.Fatalf("Expected both stripe colors, got %d colors", profile.ColorCount)
+	}
+	for _, c := range profile.Colors {
+		if abs(c.Weight-0.5) > 0.02 {
+			t.Errorf("Expected stripes to be weighted near 0.5, got RGBA(%d,%d,%d) at %.3f", c.RGBA.R, c.RGBA.G, c.RGBA.B, c.Weight)
+		}
+	}
+}
+
+func TestProcessImage_SamplingSeed(t *testing.T) {
+	img := createTexturedImage(1000, 800)
+
+	process := func(seed int64) *processor.ColorProfile {
+		s := settings.DefaultSettings()
+		s.Processor.MaxSamples = 50000
+		s.Processor.Seed = seed
+
+		profile, err := processor.New(s).ProcessImage(img)
+		if err != nil {
+			t.Fatalf("ProcessImage with seed %d failed: %v", seed, err)
+		}
+		return profile
+	}
+
+	first := process(1)
+	repeat := process(1)
+	other := process(2)
+
+	t.Logf("Seed 1: %d unique colors, fingerprint %s", first.UniqueColors, first.Fingerprint())
+	t.Logf("Seed 2: %d unique colors, fingerprint %s", other.UniqueColors, other.Fingerprint())
+
+	if first.Fingerprint() != repeat.Fingerprint() {
+		t.Error("Expected the same seed to reproduce the same profile")
+	}
+	if first.Fingerprint() == other.Fingerprint() {
+		t.Error("Expected a different seed to sample different pixels")
+	}
+	if first.SampleCount != other.SampleCount {
+		t.Errorf("Expected the seed to leave the sample count unchanged: %d vs %d", first.SampleCount, other.SampleCount)
+	}
+}
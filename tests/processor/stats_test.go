@@ -0,0 +1,125 @@
+package processor_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+// TestProcessImage_ClusterCentroid tests that a merged cluster reports its
+// weighted centroid, member count, and variance, and is represented by the
+// member nearest the centroid rather than the most frequent member
+func TestProcessImage_ClusterCentroid(t *testing.T) {
+	s := settings.DefaultSettings()
+	p := processor.New(s)
+
+	light := color.RGBA{R: 100, G: 140, B: 220, A: 255}
+	mid := color.RGBA{R: 84, G: 124, B: 204, A: 255}
+	dark := color.RGBA{R: 68, G: 108, B: 188, A: 255}
+
+	// 40% light, 30% mid, 30% dark
+	img := createTestImage(10, 10, []color.RGBA{light, light, light, light, mid, mid, mid, dark, dark, dark})
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	for i, c := range profile.Colors {
+		t.Logf("Cluster %d: %s centroid %s members %d variance %.2f weight %.2f", i,
+			formats.ToHex(c.RGBA), formats.ToHex(c.Centroid), c.Members, c.Variance, c.Weight)
+	}
+
+	if len(profile.Colors) != 1 {
+		t.Fatalf("Expected shades to merge into 1 cluster, got %d", len(profile.Colors))
+	}
+
+	cluster := profile.Colors[0]
+	if cluster.Members != 3 {
+		t.Errorf("Expected 3 members, got %d", cluster.Members)
+	}
+	if cluster.RGBA != mid {
+		t.Errorf("Expected representative %s nearest the centroid, got %s", formats.ToHex(mid), formats.ToHex(cluster.RGBA))
+	}
+	if cluster.Centroid.B <= mid.B || cluster.Centroid.B >= light.B {
+		t.Errorf("Expected centroid %s between mid and light, weighted toward light", formats.ToHex(cluster.Centroid))
+	}
+	if cluster.Variance <= 0 {
+		t.Errorf("Expected positive variance for distinct members, got %.2f", cluster.Variance)
+	}
+}
+
+// TestProcessImage_ClusterVariance tests that a single-color cluster is tight
+// and that a cluster of spread shades has a larger variance
+func TestProcessImage_ClusterVariance(t *testing.T) {
+	s := settings.DefaultSettings()
+	p := processor.New(s)
+
+	solid := color.RGBA{R: 84, G: 124, B: 204, A: 255}
+	tight, err := p.ProcessImage(createTestImage(4, 4, []color.RGBA{solid}))
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	c := tight.Colors[0]
+	t.Logf("Solid: %s centroid %s members %d variance %.2f", formats.ToHex(c.RGBA), formats.ToHex(c.Centroid), c.Members, c.Variance)
+	if c.Members != 1 || c.Variance != 0 || c.Centroid != c.RGBA {
+		t.Errorf("Expected 1 member, zero variance, and centroid equal to color")
+	}
+
+	narrow, err := p.ProcessImage(createTestImage(4, 4, []color.RGBA{
+		{R: 84, G: 124, B: 204, A: 255}, {R: 92, G: 124, B: 204, A: 255},
+	}))
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+	wide, err := p.ProcessImage(createTestImage(4, 4, []color.RGBA{
+		{R: 84, G: 124, B: 204, A: 255}, {R: 108, G: 124, B: 204, A: 255},
+	}))
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("Narrow variance %.2f, wide variance %.2f", narrow.Colors[0].Variance, wide.Colors[0].Variance)
+	if narrow.Colors[0].Members != 2 || wide.Colors[0].Members != 2 {
+		t.Fatalf("Expected 2-member clusters, got %d and %d", narrow.Colors[0].Members, wide.Colors[0].Members)
+	}
+	if wide.Colors[0].Variance <= narrow.Colors[0].Variance {
+		t.Errorf("Expected wider shades to have larger variance")
+	}
+}
+
+// TestProcessImage_KMeansClusterStats tests that k-means clusters carry the
+// same member statistics as greedy clusters
+func TestProcessImage_KMeansClusterStats(t *testing.T) {
+	s := settings.DefaultSettings()
+	s.Processor.ClusteringAlgorithm = string(processor.KMeans)
+	s.Processor.KMeansClusters = 2
+
+	profile, err := processor.New(s).ProcessImage(createRegionImage(40, 40))
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	for _, c := range profile.Colors {
+		t.Logf("%s centroid %s members %d variance %.2f", formats.ToHex(c.RGBA), formats.ToHex(c.Centroid), c.Members, c.Variance)
+		if c.Members != 3 {
+			t.Errorf("Expected 3 members per region, got %d", c.Members)
+		}
+		if c.Variance <= 0 {
+			t.Errorf("Expected positive variance, got %.2f", c.Variance)
+		}
+		if labDistance(c.RGBA, c.Centroid) > 3 {
+			t.Errorf("Expected k-means center %s near LAB centroid %s", formats.ToHex(c.RGBA), formats.ToHex(c.Centroid))
+		}
+	}
+}
+
+func labDistance(a, b color.RGBA) float64 {
+	la, lb := formats.RGBAToLAB(a), formats.RGBAToLAB(b)
+	dl, da, db := la.L-lb.L, la.A-lb.A, la.B-lb.B
+	return abs(dl) + abs(da) + abs(db)
+}
@@ -11,8 +11,8 @@ func TestNewWeightedColor(t *testing.T) {
 	testCases := []struct {
 		name           string
 		color          color.RGBA
-		frequency      uint32
-		total          uint32
+		frequency      uint64
+		total          uint64
 		expectedWeight float64
 	}{
 		{
@@ -124,8 +124,8 @@ func TestNewWeightedColor(t *testing.T) {
 func TestWeightedColor_EdgeCases(t *testing.T) {
 	t.Run("Zero total (division by zero protection)", func(t *testing.T) {
 		color := color.RGBA{R: 255, G: 128, B: 64, A: 255}
-		frequency := uint32(100)
-		total := uint32(0)
+		frequency := uint64(100)
+		total := uint64(0)
 
 		// This should handle division by zero gracefully
 		result := processor.NewWeightedColor(color, frequency, total)
@@ -148,7 +148,7 @@ func TestWeightedColor_EdgeCases(t *testing.T) {
 
 	t.Run("Maximum possible values", func(t *testing.T) {
 		color := color.RGBA{R: 255, G: 255, B: 255, A: 255}
-		maxUint32 := ^uint32(0) // Maximum uint32 value
+		maxUint32 := uint64(^uint32(0)) // Maximum uint32 value
 		frequency := maxUint32
 		total := maxUint32
 
@@ -173,8 +173,8 @@ func TestWeightedColor_EdgeCases(t *testing.T) {
 
 	t.Run("Frequency greater than total", func(t *testing.T) {
 		color := color.RGBA{R: 128, G: 64, B: 32, A: 255}
-		frequency := uint32(1500)
-		total := uint32(1000)
+		frequency := uint64(1500)
+		total := uint64(1000)
 
 		result := processor.NewWeightedColor(color, frequency, total)
 
@@ -195,8 +195,8 @@ func TestWeightedColor_EdgeCases(t *testing.T) {
 
 	t.Run("Very small weights", func(t *testing.T) {
 		color := color.RGBA{R: 1, G: 1, B: 1, A: 255}
-		frequency := uint32(1)
-		total := uint32(10000000) // 10 million
+		frequency := uint64(1)
+		total := uint64(10000000) // 10 million
 
 		result := processor.NewWeightedColor(color, frequency, total)
 
@@ -224,8 +224,8 @@ func TestWeightedColor_EdgeCases(t *testing.T) {
 func TestWeightedColor_Consistency(t *testing.T) {
 	t.Run("Multiple calls with same parameters", func(t *testing.T) {
 		color := color.RGBA{R: 200, G: 100, B: 50, A: 255}
-		frequency := uint32(750)
-		total := uint32(2000)
+		frequency := uint64(750)
+		total := uint64(2000)
 
 		// Call the function multiple times with the same parameters
 		results := make([]processor.WeightedColor, 5)
@@ -258,7 +258,7 @@ func TestWeightedColor_Consistency(t *testing.T) {
 	t.Run("Weight proportionality", func(t *testing.T) {
 		// Test that doubling frequency doubles the weight (for fixed total)
 		color := color.RGBA{R: 64, G: 128, B: 192, A: 255}
-		total := uint32(1000)
+		total := uint64(1000)
 
 		wc1 := processor.NewWeightedColor(color, 100, total) // 10%
 		wc2 := processor.NewWeightedColor(color, 200, total) // 20%
@@ -0,0 +1,236 @@
+package processor_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+)
+
+var (
+	navy   = color.RGBA{R: 30, G: 40, B: 70, A: 255}
+	red    = color.RGBA{R: 200, G: 40, B: 40, A: 255}
+	sky    = color.RGBA{R: 135, G: 180, B: 230, A: 255}
+	orange = color.RGBA{R: 220, G: 110, B: 40, A: 255}
+)
+
+// createInsetImage fills a width x height image with background and paints
+// inset over it.
+func createInsetImage(width, height int, background color.RGBA, inset image.Rectangle, fill color.RGBA) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+	draw.Draw(img, inset, &image.Uniform{C: fill}, image.Point{}, draw.Src)
+	return img
+}
+
+// weightOf processes img with the given spatial weighting and returns the
+// weight of the cluster matching target, or 0 when none matches.
+func weightOf(t *testing.T, img image.Image, weighting processor.SpatialWeighting, target color.RGBA) (float64, *processor.ColorProfile) {
+	t.Helper()
+
+	s := settings.DefaultSettings()
+	s.Processor.SpatialWeighting = string(weighting)
+	p := processor.New(s)
+
+	profile, err := p.ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage with %s weighting failed: %v", weighting, err)
+	}
+
+	c, ok := findCluster(profile, target, 8)
+	if !ok {
+		return 0, profile
+	}
+	return c.Weight, profile
+}
+
+// insetImage is a procedural image of a background with one filled
+// rectangle, so very large images need no pixel storage.
+type insetImage struct {
+	bounds     image.Rectangle
+	background color.RGBA
+	inset      image.Rectangle
+	fill       color.RGBA
+}
+
+func (m insetImage) ColorModel() color.Model { return color.RGBAModel }
+func (m insetImage) Bounds() image.Rectangle { return m.bounds }
+func (m insetImage) At(x, y int) color.Color {
+	if image.Pt(x, y).In(m.inset) {
+		return m.fill
+	}
+	return m.background
+}
+
+func TestProcessImage_EdgeWeighting(t *testing.T) {
+	// A top bar strip covering 10% of the image
+	img := createInsetImage(200, 200, navy, image.Rect(0, 0, 200, 20), red)
+
+	uniform, _ := weightOf(t, img, processor.UniformWeighting, red)
+	edge, profile := weightOf(t, img, processor.EdgeWeighting, red)
+
+	t.Logf("Top strip weight: uniform %.3f, edge %.3f", uniform, edge)
+	logClusters(t, profile)
+
+	if abs(uniform-0.1) > 0.01 {
+		t.Errorf("Expected uniform strip weight 0.1, got %.3f", uniform)
+	}
+	if edge < 2*uniform {
+		t.Errorf("Expected edge weighting to at least double the strip weight, got %.3f", edge)
+	}
+}
+
+func TestProcessImage_CenterWeighting(t *testing.T) {
+	// A centered subject covering 16% of the image
+	img := createInsetImage(200, 200, navy, image.Rect(60, 60, 140, 140), orange)
+
+	uniform, _ := weightOf(t, img, processor.UniformWeighting, orange)
+	center, profile := weightOf(t, img, processor.CenterWeighting, orange)
+
+	t.Logf("Center subject weight: uniform %.3f, center %.3f", uniform, center)
+	logClusters(t, profile)
+
+	if abs(uniform-0.16) > 0.01 {
+		t.Errorf("Expected uniform subject weight 0.16, got %.3f", uniform)
+	}
+	if center < 2*uniform {
+		t.Errorf("Expected center weighting to at least double the subject weight, got %.3f", center)
+	}
+}
+
+func TestProcessImage_SaliencyWeighting(t *testing.T) {
+	// An off-center subject against a sky that dominates by area
+	img := createInsetImage(320, 200, sky, image.Rect(180, 80, 280, 180), orange)
+
+	uniform, uniformProfile := weightOf(t, img, processor.UniformWeighting, orange)
+	salient, profile := weightOf(t, img, processor.SaliencyWeighting, orange)
+
+	t.Logf("Subject weight: uniform %.3f, saliency %.3f", uniform, salient)
+	logClusters(t, profile)
+
+	if c, ok := findCluster(uniformProfile, sky, 8); !ok || uniformProfile.Colors[0].RGBA != c.RGBA {
+		t.Errorf("Expected sky to dominate without weighting")
+	}
+	if salient <= uniform {
+		t.Errorf("Expected saliency to increase subject weight above %.3f, got %.3f", uniform, salient)
+	}
+	if c, _ := findCluster(profile, orange, 8); profile.Colors[0].RGBA != c.RGBA {
+		t.Errorf("Expected the subject to be the dominant color under saliency weighting")
+	}
+	if profile.SampleCount != uniformProfile.SampleCount {
+		t.Errorf("Expected weighting to leave the sample count unchanged: %d vs %d", profile.SampleCount, uniformProfile.SampleCount)
+	}
+}
+
+func TestProcessImage_SaliencyFlatImage(t *testing.T) {
+	img := createTestImage(100, 100, []color.RGBA{sky})
+
+	weight, profile := weightOf(t, img, processor.SaliencyWeighting, sky)
+
+	t.Logf("Flat image: %d colors, sky weight %.3f", profile.ColorCount, weight)
+
+	if weight < 0.999 {
+		t.Errorf("Expected a flat image to keep full weight, got %.3f", weight)
+	}
+}
+
+func TestProcessImage_UnknownWeightingFallsBackToUniform(t *testing.T) {
+	img := createInsetImage(200, 200, navy, image.Rect(0, 0, 200, 20), red)
+
+	s := settings.DefaultSettings()
+	uniform, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	s.Processor.SpatialWeighting = "unknown"
+	fallback, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage with unknown weighting failed: %v", err)
+	}
+
+	t.Logf("Uniform: %d colors, fallback: %d colors", uniform.ColorCount, fallback.ColorCount)
+
+	if !reflect.DeepEqual(uniform, fallback) {
+		t.Errorf("Expected unknown weighting to match uniform")
+	}
+}
+
+func TestProcessImage_WeightedTotalsDoNotOverflow(t *testing.T) {
+	// Every pixel of 8192x8192 sampled at full saliency weight sums past the
+	// uint32 range at the default weight scale
+	img := insetImage{
+		bounds:     image.Rect(0, 0, 8192, 8192),
+		background: navy,
+		inset:      image.Rect(4096, 0, 8192, 8192),
+		fill:       orange,
+	}
+
+	s := settings.DefaultSettings()
+	s.Processor.MaxSamples = 1 << 30
+	s.Processor.SpatialWeighting = string(processor.SaliencyWeighting)
+
+	profile, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("8192x8192: %d samples", profile.SampleCount)
+	logClusters(t, profile)
+
+	for _, target := range []color.RGBA{navy, orange} {
+		c, ok := findCluster(profile, target, 8)
+		if !ok {
+			t.Fatalf("Expected RGBA(%d,%d,%d) to be extracted", target.R, target.G, target.B)
+		}
+		if abs(c.Weight-0.5) > 0.01 {
+			t.Errorf("Expected each half weighted near 0.5, got %.3f", c.Weight)
+		}
+	}
+}
+
+func TestProcessImage_WeightingHoldsAtHighSampleCounts(t *testing.T) {
+	// A centered subject covering 16% of the image keeps its center-weighted
+	// share however many pixels are sampled
+	small, _ := weightOf(t, createInsetImage(200, 200, navy, image.Rect(60, 60, 140, 140), orange), processor.CenterWeighting, orange)
+
+	img := insetImage{
+		bounds:     image.Rect(0, 0, 8192, 8192),
+		background: navy,
+		inset:      image.Rect(2458, 2458, 5734, 5734),
+		fill:       orange,
+	}
+
+	s := settings.DefaultSettings()
+	s.Processor.MaxSamples = 1 << 30
+	s.Processor.SpatialWeighting = string(processor.CenterWeighting)
+
+	profile, err := processor.New(s).ProcessImage(img)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	t.Logf("8192x8192: %d samples", profile.SampleCount)
+	logClusters(t, profile)
+
+	if profile.SampleCount != 8192*8192 {
+		t.Errorf("Expected every pixel sampled, got %d", profile.SampleCount)
+	}
+
+	c, ok := findCluster(profile, orange, 8)
+	if !ok {
+		t.Fatal("Expected the centered subject to be extracted")
+	}
+	t.Logf("Center subject weight: 200x200 %.3f, 8192x8192 %.3f", small, c.Weight)
+
+	if c.Weight < 0.32 {
+		t.Errorf("Expected center weighting to at least double the 0.16 subject weight, got %.3f", c.Weight)
+	}
+	if abs(c.Weight-small) > 0.02 {
+		t.Errorf("Expected the subject weight to match the small image %.3f, got %.3f", small, c.Weight)
+	}
+}
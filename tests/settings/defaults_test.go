@@ -122,7 +122,13 @@ func TestDefaultSettings_ChromaticThresholds(t *testing.T) {
 		"MutedSaturationMax":    0.3,
 		"VibrantSaturationMin":  0.7,
 		"ColorMergeThreshold":   15.0,
+		"CIE94MergeThreshold":   7.5,
+		"CIEDE2000MergeThreshold": 7.0,
 		"NeutralLightnessThreshold": 0.08,
+		"AnalogousSpread":       30.0,
+		"SplitComplementaryAngle": 30.0,
+		"MonochromaticLightnessStep": 0.15,
+		"HarmonyHueTolerance":   20.0,
 	}
 
 	actualValues := map[string]float64{
@@ -132,7 +138,13 @@ func TestDefaultSettings_ChromaticThresholds(t *testing.T) {
 		"MutedSaturationMax":    s.Chromatic.MutedSaturationMax,
 		"VibrantSaturationMin":  s.Chromatic.VibrantSaturationMin,
 		"ColorMergeThreshold":   s.Chromatic.ColorMergeThreshold,
+		"CIE94MergeThreshold":   s.Chromatic.CIE94MergeThreshold,
+		"CIEDE2000MergeThreshold": s.Chromatic.CIEDE2000MergeThreshold,
 		"NeutralLightnessThreshold": s.Chromatic.NeutralLightnessThreshold,
+		"AnalogousSpread":       s.Chromatic.AnalogousSpread,
+		"SplitComplementaryAngle": s.Chromatic.SplitComplementaryAngle,
+		"MonochromaticLightnessStep": s.Chromatic.MonochromaticLightnessStep,
+		"HarmonyHueTolerance":   s.Chromatic.HarmonyHueTolerance,
 	}
 
 	for name, expected := range expectedValues {
@@ -141,6 +153,14 @@ func TestDefaultSettings_ChromaticThresholds(t *testing.T) {
 			t.Errorf("%s: expected %.3f, got %.3f", name, expected, actual)
 		}
 	}
+
+	if s.Chromatic.DistanceMetric != "cie76" {
+		t.Errorf("DistanceMetric: expected cie76, got %s", s.Chromatic.DistanceMetric)
+	}
+
+	if s.Chromatic.DeficiencyDistanceThreshold != 10.0 {
+		t.Errorf("DeficiencyDistanceThreshold: expected 10.0, got %.1f", s.Chromatic.DeficiencyDistanceThreshold)
+	}
 }
 
 func TestDefaultSettings_ProcessorSettings(t *testing.T) {
@@ -157,6 +177,7 @@ func TestDefaultSettings_ProcessorSettings(t *testing.T) {
 	// Expected values based on defaults.go
 	expectedValues := map[string]interface{}{
 		"MinFrequency":              0.0001,
+		"MaxSamples":                1000000,
 		"MinClusterWeight":          0.005,
 		"MinUIColorWeight":          0.01,
 		"MaxUIColors":               20,
@@ -165,10 +186,20 @@ func TestDefaultSettings_ProcessorSettings(t *testing.T) {
 		"SignificantColorThreshold": 0.1,
 		"PureBlackThreshold":        0.01,
 		"PureWhiteThreshold":        0.99,
+		"KMeansClusters":            12,
+		"KMeansMaxIterations":       25,
+		"ExtractorColors":           64,
+		"MinAlpha":                  16,
+		"CenterSigma":               0.25,
+		"EdgeSigma":                 0.1,
+		"SaliencyGrid":              16,
+		"RegionBarHeight":           32,
+		"RegionCenterFraction":      0.5,
 	}
 
 	actualValues := map[string]interface{}{
 		"MinFrequency":              s.Processor.MinFrequency,
+		"MaxSamples":                s.Processor.MaxSamples,
 		"MinClusterWeight":          s.Processor.MinClusterWeight,
 		"MinUIColorWeight":          s.Processor.MinUIColorWeight,
 		"MaxUIColors":               s.Processor.MaxUIColors,
@@ -177,6 +208,15 @@ func TestDefaultSettings_ProcessorSettings(t *testing.T) {
 		"SignificantColorThreshold": s.Processor.SignificantColorThreshold,
 		"PureBlackThreshold":        s.Processor.PureBlackThreshold,
 		"PureWhiteThreshold":        s.Processor.PureWhiteThreshold,
+		"KMeansClusters":            s.Processor.KMeansClusters,
+		"KMeansMaxIterations":       s.Processor.KMeansMaxIterations,
+		"ExtractorColors":           s.Processor.ExtractorColors,
+		"MinAlpha":                  s.Processor.MinAlpha,
+		"CenterSigma":               s.Processor.CenterSigma,
+		"EdgeSigma":                 s.Processor.EdgeSigma,
+		"SaliencyGrid":              s.Processor.SaliencyGrid,
+		"RegionBarHeight":           s.Processor.RegionBarHeight,
+		"RegionCenterFraction":      s.Processor.RegionCenterFraction,
 	}
 
 	for name, expected := range expectedValues {
@@ -194,6 +234,78 @@ func TestDefaultSettings_ProcessorSettings(t *testing.T) {
 			}
 		}
 	}
+
+	if s.Processor.ClusteringAlgorithm != "greedy" {
+		t.Errorf("ClusteringAlgorithm: expected greedy, got %s", s.Processor.ClusteringAlgorithm)
+	}
+	if s.Processor.Extractor != "histogram" {
+		t.Errorf("Extractor: expected histogram, got %s", s.Processor.Extractor)
+	}
+	if s.Processor.KMeansColorSpace != "oklab" {
+		t.Errorf("KMeansColorSpace: expected oklab, got %s", s.Processor.KMeansColorSpace)
+	}
+	if s.Processor.AlphaMatte != "" {
+		t.Errorf("AlphaMatte: expected empty, got %s", s.Processor.AlphaMatte)
+	}
+	if s.Processor.SpatialWeighting != "uniform" {
+		t.Errorf("SpatialWeighting: expected uniform, got %s", s.Processor.SpatialWeighting)
+	}
+}
+
+func TestDefaultSettings_PaletteSettings(t *testing.T) {
+	s := settings.DefaultSettings()
+
+	t.Logf("Palette settings:")
+	t.Logf("  Dark background lightness: %.3f", s.Palette.DarkBackgroundLightness)
+	t.Logf("  Light background lightness: %.3f", s.Palette.LightBackgroundLightness)
+	t.Logf("  Accent hue separation: %.1f°", s.Palette.AccentHueSeparation)
+
+	// Expected values based on defaults.go
+	expectedValues := map[string]float64{
+		"DarkBackgroundLightness":  0.12,
+		"LightBackgroundLightness": 0.94,
+		"DarkForegroundLightness":  0.88,
+		"LightForegroundLightness": 0.18,
+		"BackgroundSaturationMax":  0.35,
+		"AccentHueSeparation":      30.0,
+		"DimForegroundMix":         0.35,
+		"SelectionMix":             0.6,
+		"ANSIHueTolerance":         25.0,
+		"ANSISaturationMin":        0.35,
+		"ANSILightnessMin":         0.45,
+		"ANSILightnessMax":         0.7,
+		"ANSIBrightLightnessDelta": 0.1,
+		"SchemeHueTolerance":       20.0,
+		"SchemeMinConfidence":      0.5,
+	}
+
+	actualValues := map[string]float64{
+		"DarkBackgroundLightness":  s.Palette.DarkBackgroundLightness,
+		"LightBackgroundLightness": s.Palette.LightBackgroundLightness,
+		"DarkForegroundLightness":  s.Palette.DarkForegroundLightness,
+		"LightForegroundLightness": s.Palette.LightForegroundLightness,
+		"BackgroundSaturationMax":  s.Palette.BackgroundSaturationMax,
+		"AccentHueSeparation":      s.Palette.AccentHueSeparation,
+		"DimForegroundMix":         s.Palette.DimForegroundMix,
+		"SelectionMix":             s.Palette.SelectionMix,
+		"ANSIHueTolerance":         s.Palette.ANSIHueTolerance,
+		"ANSISaturationMin":        s.Palette.ANSISaturationMin,
+		"ANSILightnessMin":         s.Palette.ANSILightnessMin,
+		"ANSILightnessMax":         s.Palette.ANSILightnessMax,
+		"ANSIBrightLightnessDelta": s.Palette.ANSIBrightLightnessDelta,
+		"SchemeHueTolerance":       s.Palette.SchemeHueTolerance,
+		"SchemeMinConfidence":      s.Palette.SchemeMinConfidence,
+	}
+
+	for name, expected := range expectedValues {
+		if actual := actualValues[name]; math.Abs(actual-expected) > 0.0001 {
+			t.Errorf("%s: expected %.6f, got %.6f", name, expected, actual)
+		}
+	}
+
+	if s.Palette.BorderContrastLevel != "non-text" {
+		t.Errorf("BorderContrastLevel: expected non-text, got %s", s.Palette.BorderContrastLevel)
+	}
 }
 
 func TestDefaultSettings_GlobalSettings(t *testing.T) {
@@ -263,6 +375,18 @@ func TestDefaultSettings_FormatsSettings(t *testing.T) {
 	}
 }
 
+func TestDefaultSettings_ThemeSettings(t *testing.T) {
+	s := settings.DefaultSettings()
+
+	t.Logf("Theme settings:")
+	t.Logf("  Themes directory: %s", s.Theme.ThemesDir)
+
+	expected := "~/.config/omarchy/themes"
+	if s.Theme.ThemesDir != expected {
+		t.Errorf("Expected themes directory %s, got %s", expected, s.Theme.ThemesDir)
+	}
+}
+
 func TestSettings_WithContext(t *testing.T) {
 	s := settings.DefaultSettings()
 	ctx := context.Background()
@@ -2,11 +2,15 @@ package settings_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/choices"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/chromatic"
 	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
 )
 
@@ -162,6 +166,171 @@ invalid_yaml: [
 	t.Logf("Successfully handled invalid config file")
 }
 
+func TestSettings_Load_InvalidContrastLevel(t *testing.T) {
+	testCases := []struct {
+		key   string
+		value string
+	}{
+		{"text_contrast_level", "AAA-lrg"},
+		{"accent_contrast_level", "A"},
+		{"border_contrast_level", "nontext"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "contrast-settings.yaml")
+			configContent := fmt.Sprintf("palette:\n  %s: %q\n", tc.key, tc.value)
+			if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+			t.Setenv("OMARCHY_CONFIG", configPath)
+
+			s, err := settings.Load()
+			if err == nil {
+				t.Fatalf("Load() should reject %s %q", tc.key, tc.value)
+			}
+			t.Logf("Load() rejected %s: %v", tc.key, err)
+
+			if s != nil {
+				t.Error("Load() should return nil settings for an invalid contrast level")
+			}
+			for _, want := range []string{"palette." + tc.key, tc.value, strings.Join(choices.AccessibilityLevels, ", ")} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to mention %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestSettings_Load_InvalidChoice(t *testing.T) {
+	testCases := []struct {
+		section string
+		key     string
+		value   string
+		names   []string
+	}{
+		{"chromatic", "distance_metric", "cie2000", choices.DistanceMetrics},
+		{"processor", "extractor", "mediancut", choices.Extractors},
+		{"processor", "spatial_weighting", "centre", choices.SpatialWeightings},
+		{"processor", "clustering_algorithm", "k-means", choices.ClusteringAlgorithms},
+		{"processor", "kmeans_color_space", "cielab", choices.KMeansColorSpaces},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "choice-settings.yaml")
+			configContent := fmt.Sprintf("%s:\n  %s: %q\n", tc.section, tc.key, tc.value)
+			if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+			t.Setenv("OMARCHY_CONFIG", configPath)
+
+			s, err := settings.Load()
+			if err == nil {
+				t.Fatalf("Load() should reject %s.%s %q", tc.section, tc.key, tc.value)
+			}
+			t.Logf("Load() rejected %s.%s: %v", tc.section, tc.key, err)
+
+			if s != nil {
+				t.Errorf("Load() should return nil settings for an invalid %s", tc.key)
+			}
+			for _, want := range []string{tc.section + "." + tc.key, tc.value, strings.Join(tc.names, ", ")} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to mention %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestSettings_Load_InvalidHarmonyHueTolerance(t *testing.T) {
+	for _, tolerance := range []string{"0", "-5"} {
+		t.Run(tolerance, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "tolerance-settings.yaml")
+			configContent := "chromatic:\n  harmony_hue_tolerance: " + tolerance + "\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+			t.Setenv("OMARCHY_CONFIG", configPath)
+
+			_, err := settings.Load()
+			if err == nil {
+				t.Fatalf("Expected Load() to reject harmony_hue_tolerance %s", tolerance)
+			}
+			t.Logf("Rejected: %v", err)
+
+			if !strings.Contains(err.Error(), "harmony_hue_tolerance") {
+				t.Errorf("Expected error to name the setting, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSettings_Load_NormalizesChoices(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "choice-settings.yaml")
+	configContent := `
+chromatic:
+  distance_metric: " CIEDE2000 "
+processor:
+  clustering_algorithm: "KMeans"
+palette:
+  accent_contrast_level: "aa"
+  border_contrast_level: "Non-Text"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	t.Setenv("OMARCHY_CONFIG", configPath)
+
+	s, err := settings.Load()
+	if err != nil {
+		t.Fatalf("Load() should accept names in any case: %v", err)
+	}
+
+	testCases := []struct {
+		key      string
+		value    string
+		expected string
+	}{
+		{"chromatic.distance_metric", s.Chromatic.DistanceMetric, choices.CIEDE2000},
+		{"processor.clustering_algorithm", s.Processor.ClusteringAlgorithm, choices.KMeans},
+		{"palette.accent_contrast_level", s.Palette.AccentContrastLevel, choices.AA},
+		{"palette.border_contrast_level", s.Palette.BorderContrastLevel, choices.NonText},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("%s: %q", tc.key, tc.value)
+		if tc.value != tc.expected {
+			t.Errorf("Expected %s to load as %q, got %q", tc.key, tc.expected, tc.value)
+		}
+	}
+}
+
+func TestSettings_ContrastLevelsMatchChromatic(t *testing.T) {
+	for _, name := range choices.AccessibilityLevels {
+		s := settings.DefaultSettings()
+		s.Palette.TextContrastLevel = name
+		if err := s.Validate(); err != nil {
+			t.Errorf("Expected settings to accept %s: %v", name, err)
+		}
+
+		level, err := chromatic.ParseAccessibilityLevel(name)
+		if err != nil {
+			t.Errorf("Expected chromatic to parse %s: %v", name, err)
+		}
+		t.Logf("Level %s: ratio %.1f", name, level.Ratio())
+	}
+
+	if len(chromatic.AccessibilityLevels) != len(choices.AccessibilityLevels) {
+		t.Errorf("Expected %d chromatic levels, got %d", len(choices.AccessibilityLevels), len(chromatic.AccessibilityLevels))
+	}
+
+	if err := settings.DefaultSettings().Validate(); err != nil {
+		t.Errorf("Default settings should validate: %v", err)
+	}
+}
+
 func TestSettings_ViperIntegration(t *testing.T) {
 	// Test direct Viper integration
 	v := viper.New()
@@ -0,0 +1,508 @@
+package theme_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	themeerrors "github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/theme"
+)
+
+func TestWrite_WritesMetadata(t *testing.T) {
+	w, _ := newWriter(t)
+	profile := testProfile()
+	pal := testPalette(processor.Dark)
+
+	path, err := w.Write("meta", profile, pal, sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(path, theme.MetadataFile))
+	if err != nil {
+		t.Fatalf("Read metadata: %v", err)
+	}
+	t.Logf("%s:\n%s", theme.MetadataFile, data)
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Metadata is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"version", "source_image", "extracted_colors", "analysis", "generation", "overrides"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("Metadata missing top-level key %q", key)
+		}
+	}
+
+	overrides, _ := raw["overrides"].(map[string]any)
+	for _, key := range []string{"background", "foreground", "primary"} {
+		if v, ok := overrides[key]; !ok || v != nil {
+			t.Errorf("Expected override %q to be written as null, got %v", key, v)
+		}
+	}
+
+	meta, err := w.LoadMetadata("meta")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+
+	if meta.Version != theme.MetadataVersion {
+		t.Errorf("Expected version %s, got %s", theme.MetadataVersion, meta.Version)
+	}
+	if meta.SourceImage != "backgrounds/"+filepath.Base(sourceImage) {
+		t.Errorf("Unexpected source image %s", meta.SourceImage)
+	}
+	if meta.ExtractedColors.Dominant != "#24273aff" {
+		t.Errorf("Expected dominant #24273aff, got %s", meta.ExtractedColors.Dominant)
+	}
+	if cov := meta.ExtractedColors.CoverageMap["#24273aff"]; cov.Pixels != 5500 || cov.Percentage != 55 {
+		t.Errorf("Unexpected dominant coverage: %+v", cov)
+	}
+	if meta.Generation.Mode != "dark" || meta.Generation.Scheme != string(palette.SchemeExtracted) {
+		t.Errorf("Unexpected generation mode/scheme: %s/%s", meta.Generation.Mode, meta.Generation.Scheme)
+	}
+	if meta.Generation.Background != "#24273aff" || meta.Generation.Accent1 != "#8aadf4ff" {
+		t.Errorf("Unexpected generation roles: %+v", meta.Generation)
+	}
+	if meta.Generation.Timestamp.IsZero() {
+		t.Errorf("Expected generation timestamp")
+	}
+
+	t.Logf("Detected scheme: %s (%.2f)", meta.Analysis.DetectedScheme, meta.Analysis.SchemeConfidence)
+	if _, err := palette.ParseScheme(meta.Analysis.DetectedScheme); err != nil || meta.Analysis.DetectedScheme == "" {
+		t.Errorf("Expected a valid detected scheme, got %q", meta.Analysis.DetectedScheme)
+	}
+	if meta.Analysis.SchemeConfidence < 0 || meta.Analysis.SchemeConfidence > 1 {
+		t.Errorf("Scheme confidence %.2f out of range", meta.Analysis.SchemeConfidence)
+	}
+}
+
+func TestMetadata_ProfileRoundTrip(t *testing.T) {
+	w, _ := newWriter(t)
+	profile := testProfile()
+	profile.Colors[0].Centroid, _ = formats.ParseHex("#262a3c")
+	profile.Colors[0].Members = 12
+	profile.Colors[0].Variance = 4.5
+
+	data, err := theme.NewMetadata(profile, testPalette(processor.Dark), sourceImage).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	meta, err := w.ParseMetadata(data)
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+
+	restored, err := meta.Profile()
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+
+	if restored.Mode != profile.Mode || restored.HasColor != profile.HasColor ||
+		restored.ColorCount != profile.ColorCount || restored.UniqueColors != profile.UniqueColors ||
+		restored.SampleCount != profile.SampleCount {
+		t.Errorf("Profile summary mismatch:\n  original: %+v\n  restored: %+v", *profile, *restored)
+	}
+
+	for i := range profile.Colors {
+		if restored.Colors[i] != profile.Colors[i] {
+			t.Errorf("Cluster %d mismatch:\n  original: %+v\n  restored: %+v", i, profile.Colors[i], restored.Colors[i])
+		}
+	}
+
+	t.Logf("Fingerprint: %s", meta.ExtractedColors.Fingerprint)
+	if meta.ExtractedColors.Fingerprint != profile.Fingerprint() {
+		t.Errorf("Stored fingerprint %s does not match profile %s", meta.ExtractedColors.Fingerprint, profile.Fingerprint())
+	}
+	if restored.Fingerprint() != profile.Fingerprint() {
+		t.Errorf("Restored profile fingerprint %s does not match original %s", restored.Fingerprint(), profile.Fingerprint())
+	}
+
+	t.Logf("Analysis: %+v", meta.Analysis)
+	if meta.Analysis.IsGrayscale || meta.Analysis.IsMonochromatic {
+		t.Errorf("Expected chromatic, multi-hue analysis")
+	}
+	if meta.Analysis.AverageLuminance <= 0 || meta.Analysis.AverageLuminance >= 1 {
+		t.Errorf("Average luminance %.2f out of range", meta.Analysis.AverageLuminance)
+	}
+	if meta.Analysis.PerceptualDiversity <= 0 || meta.Analysis.PerceptualDiversity > 1 {
+		t.Errorf("Perceptual diversity %.2f out of range", meta.Analysis.PerceptualDiversity)
+	}
+}
+
+func TestRegenerate_HonorsOverrides(t *testing.T) {
+	w, _ := newWriter(t)
+
+	path, err := w.Write("override", testProfile(), testPalette(processor.Dark), sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	meta, err := w.LoadMetadata("override")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+
+	pinned := "#ff00ffff"
+	meta.Overrides.Primary = &pinned
+	writeMetadata(t, path, meta)
+
+	if _, err := w.Regenerate("override", theme.Changes{}); err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+
+	hyprland, err := os.ReadFile(filepath.Join(path, "hyprland.conf"))
+	if err != nil {
+		t.Fatalf("Read hyprland.conf: %v", err)
+	}
+	if !strings.Contains(string(hyprland), "rgb(ff00ff)") {
+		t.Errorf("Expected pinned primary rgb(ff00ff) in hyprland.conf:\n%s", hyprland)
+	}
+
+	rendered, err := w.LoadMetadata("override")
+	if err != nil {
+		t.Fatalf("LoadMetadata after regenerate failed: %v", err)
+	}
+	t.Logf("Generation after regenerate: %+v", rendered.Generation)
+
+	if rendered.Generation.Primary != pinned {
+		t.Errorf("Expected generation primary %s, got %s", pinned, rendered.Generation.Primary)
+	}
+	if rendered.Overrides.Primary == nil || *rendered.Overrides.Primary != pinned {
+		t.Errorf("Expected primary override to persist across regenerate")
+	}
+	if rendered.Overrides.Background != nil {
+		t.Errorf("Expected unset overrides to remain null")
+	}
+	if _, err := os.Stat(filepath.Join(path, rendered.SourceImage)); err != nil {
+		t.Errorf("Regenerate lost source image: %v", err)
+	}
+}
+
+func TestRegenerate_UsesGenerationMode(t *testing.T) {
+	w, _ := newWriter(t)
+
+	path, err := w.Write("mode", testProfile(), testPalette(processor.Dark), sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	meta, err := w.LoadMetadata("mode")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	meta.Generation.Mode = "light"
+	writeMetadata(t, path, meta)
+
+	if _, err := w.Regenerate("mode", theme.Changes{}); err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(path, theme.LightModeFile)); err != nil {
+		t.Errorf("Expected %s after regenerating in light mode", theme.LightModeFile)
+	}
+
+	rendered, err := w.LoadMetadata("mode")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if rendered.Analysis.DetectedMode != "dark" {
+		t.Errorf("Regenerate should keep detected mode dark, got %s", rendered.Analysis.DetectedMode)
+	}
+
+	bg, _ := formats.ParseHex(rendered.Generation.Background)
+	fg, _ := formats.ParseHex(rendered.Generation.Foreground)
+	if formats.RGBAToHSLA(bg).L <= formats.RGBAToHSLA(fg).L {
+		t.Errorf("Expected light background after mode change: bg %s fg %s",
+			rendered.Generation.Background, rendered.Generation.Foreground)
+	}
+}
+
+func TestRegenerate_AppliesChanges(t *testing.T) {
+	w, _ := newWriter(t)
+
+	path, err := w.Write("changes", testProfile(), testPalette(processor.Dark), sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if _, err := w.Regenerate("changes", theme.Changes{Scheme: palette.SchemeTriadic}); err != nil {
+		t.Fatalf("Regenerate scheme failed: %v", err)
+	}
+	if _, err := w.Regenerate("changes", theme.Changes{Mode: processor.Light}); err != nil {
+		t.Fatalf("Regenerate mode failed: %v", err)
+	}
+
+	meta, err := w.LoadMetadata("changes")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	t.Logf("Generation: %+v", meta.Generation)
+
+	if meta.Generation.Scheme != string(palette.SchemeTriadic) {
+		t.Errorf("Expected scheme to persist across mode change, got %s", meta.Generation.Scheme)
+	}
+	if meta.Generation.Mode != "light" {
+		t.Errorf("Expected light mode, got %s", meta.Generation.Mode)
+	}
+	if _, err := os.Stat(filepath.Join(path, theme.LightModeFile)); err != nil {
+		t.Errorf("Expected %s after set-mode light", theme.LightModeFile)
+	}
+	assertNoStaging(t, filepath.Dir(path))
+
+	if _, err := w.Regenerate("missing", theme.Changes{}); !errors.Is(err, themeerrors.ErrThemeNotFound) {
+		t.Errorf("Expected ErrThemeNotFound for missing theme, got %v", err)
+	}
+}
+
+func TestRegenerate_KeepsFingerprint(t *testing.T) {
+	w, _ := newWriter(t)
+
+	if _, err := w.Write("fingerprint", testProfile(), testPalette(processor.Dark), sourceImage); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	original, err := w.LoadMetadata("fingerprint")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	fingerprint := original.ExtractedColors.Fingerprint
+	t.Logf("Original fingerprint: %s", fingerprint)
+
+	if _, err := w.Regenerate("fingerprint", theme.Changes{Mode: processor.Light}); err != nil {
+		t.Fatalf("Regenerate mode failed: %v", err)
+	}
+	if _, err := w.Clone("fingerprint", "fingerprint-clone", theme.Changes{Mode: processor.Dark}); err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	for _, name := range []string{"fingerprint", "fingerprint-clone"} {
+		meta, err := w.LoadMetadata(name)
+		if err != nil {
+			t.Fatalf("LoadMetadata %s failed: %v", name, err)
+		}
+		t.Logf("%s: mode %s, fingerprint %s", name, meta.Generation.Mode, meta.ExtractedColors.Fingerprint)
+
+		if meta.ExtractedColors.Fingerprint != fingerprint {
+			t.Errorf("Changing the mode of %s changed the extraction fingerprint", name)
+		}
+		if meta.Analysis.DetectedMode != original.Analysis.DetectedMode {
+			t.Errorf("Expected %s to keep detected mode %s, got %s", name, original.Analysis.DetectedMode, meta.Analysis.DetectedMode)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	w, _ := newWriter(t)
+
+	sourcePath, err := w.Write("source", testProfile(), testPalette(processor.Dark), sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	before, err := os.ReadFile(filepath.Join(sourcePath, theme.MetadataFile))
+	if err != nil {
+		t.Fatalf("Read source metadata: %v", err)
+	}
+
+	clonePath, err := w.Clone("source", "copy", theme.Changes{Scheme: palette.SchemeMonochromatic})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	clone, err := w.LoadMetadata("copy")
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	t.Logf("Clone generation: %+v", clone.Generation)
+
+	if clone.Generation.Scheme != string(palette.SchemeMonochromatic) {
+		t.Errorf("Expected clone scheme monochromatic, got %s", clone.Generation.Scheme)
+	}
+	if clone.Generation.Mode != "dark" {
+		t.Errorf("Expected clone to keep source mode dark, got %s", clone.Generation.Mode)
+	}
+	if _, err := os.Stat(filepath.Join(clonePath, clone.SourceImage)); err != nil {
+		t.Errorf("Clone missing source image: %v", err)
+	}
+
+	after, err := os.ReadFile(filepath.Join(sourcePath, theme.MetadataFile))
+	if err != nil {
+		t.Fatalf("Read source metadata: %v", err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("Clone modified the source theme metadata")
+	}
+
+	if _, err := w.Clone("source", "copy", theme.Changes{}); !errors.Is(err, themeerrors.ErrThemeExists) {
+		t.Errorf("Expected ErrThemeExists cloning onto existing theme, got %v", err)
+	}
+}
+
+func TestParseMetadata_MigratesV1_0(t *testing.T) {
+	w, _ := newWriter(t)
+
+	// Schema documented in OMARCHY.md
+	legacy := `{
+  "version": "1.0.0",
+  "source_image": "backgrounds/photo.jpg",
+  "extracted_colors": {
+    "dominant": "#2e3440ff",
+    "palette": ["#2e3440ff", "#88c0d0ff", "#81a1c1ff"],
+    "unique_count": 1247,
+    "coverage_map": {
+      "#2e3440ff": {"pixels": 28470, "percentage": 34.2},
+      "#88c0d0ff": {"pixels": 8324, "percentage": 10.0},
+      "#81a1c1ff": {"pixels": 4162, "percentage": 5.0}
+    }
+  },
+  "analysis": {
+    "is_grayscale": false,
+    "is_monochromatic": false,
+    "average_luminance": 0.42,
+    "perceptual_diversity": 0.67
+  },
+  "generation": {
+    "mode": "dark",
+    "scheme": "complementary",
+    "primary": "#88c0d0ff",
+    "background": "#2e3440ff",
+    "foreground": "#eceff4ff",
+    "accent1": "#bf616aff",
+    "accent2": "#d08770ff",
+    "accent3": "#ebcb8bff",
+    "timestamp": "2025-08-31T10:30:00Z"
+  },
+  "overrides": {
+    "background": null,
+    "foreground": "#eceff4ff",
+    "primary": null
+  }
+}`
+
+	meta, err := w.ParseMetadata([]byte(legacy))
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+
+	t.Logf("Migrated to %s with %d clusters, %d samples", meta.Version, len(meta.ExtractedColors.Clusters), meta.ExtractedColors.SampleCount)
+
+	if meta.Version != theme.MetadataVersion {
+		t.Errorf("Expected migration to %s, got %s", theme.MetadataVersion, meta.Version)
+	}
+	if meta.Analysis.DetectedMode != "dark" {
+		t.Errorf("Expected detected mode from generation, got %q", meta.Analysis.DetectedMode)
+	}
+	if meta.ExtractedColors.SampleCount != 83246 {
+		t.Errorf("Expected sample count derived from coverage, got %d", meta.ExtractedColors.SampleCount)
+	}
+
+	profile, err := meta.Profile()
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	if len(profile.Colors) != 3 {
+		t.Fatalf("Expected 3 clusters, got %d", len(profile.Colors))
+	}
+	if profile.Colors[0].Weight != 0.342 || !profile.Colors[0].IsDark {
+		t.Errorf("Unexpected dominant cluster: %+v", profile.Colors[0])
+	}
+
+	overrides, err := meta.Overrides.Palette()
+	if err != nil {
+		t.Fatalf("Overrides failed: %v", err)
+	}
+	if overrides.Foreground == nil || formats.ToHex(*overrides.Foreground) != "#ECEFF4" {
+		t.Errorf("Expected foreground override to parse")
+	}
+	if overrides.Background != nil || overrides.Primary != nil {
+		t.Errorf("Expected null overrides to stay unset")
+	}
+	if meta.Analysis.DetectedScheme == "" {
+		t.Errorf("Expected migration to detect a scheme")
+	}
+}
+
+func TestParseMetadata_MigratesV1_1(t *testing.T) {
+	w, _ := newWriter(t)
+
+	meta := theme.NewMetadata(testProfile(), testPalette(processor.Dark), sourceImage)
+	meta.Version = "1.1.0"
+	data, err := meta.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	migrated, err := w.ParseMetadata(data)
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+
+	profile, err := migrated.Profile()
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	expected, _ := palette.New(settings.DefaultSettings()).DetectScheme(profile)
+
+	t.Logf("Migrated to %s, detected %s (%.2f)", migrated.Version, migrated.Analysis.DetectedScheme, migrated.Analysis.SchemeConfidence)
+
+	if migrated.Version != theme.MetadataVersion {
+		t.Errorf("Expected migration to %s, got %s", theme.MetadataVersion, migrated.Version)
+	}
+	if migrated.Analysis.DetectedScheme != string(expected) {
+		t.Errorf("Expected detected scheme %s, got %s", expected, migrated.Analysis.DetectedScheme)
+	}
+}
+
+func TestParseMetadata_Errors(t *testing.T) {
+	w, _ := newWriter(t)
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected error
+	}{
+		{"Invalid JSON", `{"version": `, themeerrors.ErrInvalidMetadata},
+		{"Missing version", `{"source_image": "backgrounds/a.jpg"}`, themeerrors.ErrInvalidMetadata},
+		{"Unknown version", `{"version": "9.0.0"}`, themeerrors.ErrUnsupportedMetadataVersion},
+		{"Invalid palette color", `{"version": "1.0.0", "extracted_colors": {"palette": ["#zzzzzz"]}}`, themeerrors.ErrInvalidMetadata},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := w.ParseMetadata([]byte(tc.data))
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, err)
+			}
+
+			var metaErr *themeerrors.MetadataError
+			if !errors.As(err, &metaErr) {
+				t.Errorf("Expected MetadataError, got %T", err)
+			}
+			t.Logf("Error: %v", err)
+		})
+	}
+
+	if _, err := w.LoadMetadata("missing"); !errors.Is(err, themeerrors.ErrThemeNotFound) {
+		t.Errorf("Expected ErrThemeNotFound for missing theme, got %v", err)
+	}
+}
+
+// writeMetadata replaces theme-gen.json in a theme directory.
+func writeMetadata(t *testing.T, themePath string, meta *theme.Metadata) {
+	t.Helper()
+	data, err := meta.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(themePath, theme.MetadataFile), data, 0644); err != nil {
+		t.Fatalf("Write metadata: %v", err)
+	}
+}
@@ -0,0 +1,304 @@
+package theme_test
+
+import (
+	"errors"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	themeerrors "github.com/JaimeStill/omarchy-theme-generator/pkg/errors"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/formats"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/palette"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/processor"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/settings"
+	"github.com/JaimeStill/omarchy-theme-generator/pkg/theme"
+)
+
+var sourceImage = filepath.Join("..", "images", "monochrome.jpeg")
+
+func TestWrite_CreatesThemeDirectory(t *testing.T) {
+	w, dir := newWriter(t)
+	pal := testPalette(processor.Dark)
+
+	path, err := w.Write("test-dark", testProfile(), pal, sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	t.Logf("Theme written to: %s", path)
+
+	if path != filepath.Join(dir, "test-dark") {
+		t.Errorf("Expected path %s, got %s", filepath.Join(dir, "test-dark"), path)
+	}
+
+	for _, file := range theme.ComponentFiles {
+		info, err := os.Stat(filepath.Join(path, file))
+		if err != nil {
+			t.Errorf("Missing component file %s: %v", file, err)
+			continue
+		}
+		t.Logf("  %s (%d bytes, %v)", file, info.Size(), info.Mode().Perm())
+		if info.Mode().Perm() != 0644 {
+			t.Errorf("%s: expected mode 0644, got %v", file, info.Mode().Perm())
+		}
+	}
+
+	bg := filepath.Join(path, theme.BackgroundsDir, filepath.Base(sourceImage))
+	if _, err := os.Stat(bg); err != nil {
+		t.Errorf("Expected source image copied to %s: %v", bg, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(path, theme.LightModeFile)); !os.IsNotExist(err) {
+		t.Errorf("Dark theme should not contain %s", theme.LightModeFile)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat theme directory: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected theme directory mode 0755, got %v", info.Mode().Perm())
+	}
+
+	assertNoStaging(t, dir)
+}
+
+func TestWrite_ComponentFormats(t *testing.T) {
+	w, _ := newWriter(t)
+	pal := testPalette(processor.Dark)
+
+	path, err := w.Write("formats", testProfile(), pal, sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	testCases := []struct {
+		file     string
+		expected string
+	}{
+		{"alacritty.toml", `background = "#24273a"`},
+		{"alacritty.toml", `foreground = "#cad3f5"`},
+		{"btop.theme", `theme[main_bg]="#24273a"`},
+		{"hyprland.conf", `$activeBorderColor = rgb(c6d0f5)`},
+		{"hyprlock.conf", `$color = rgba(36, 39, 58, 1.0)`},
+		{"hyprlock.conf", `$inner_color = rgba(36, 39, 58, 0.8)`},
+		{"mako.ini", `text-color=#cad3f5`},
+		{"neovim.lua", `base00 = "#24273a"`},
+		{"swayosd.css", `@define-color background-color #24273a;`},
+		{"walker.css", `@define-color base #24273a;`},
+		{"waybar.css", `@define-color foreground #cad3f5;`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.file+"/"+tc.expected, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join(path, tc.file))
+			if err != nil {
+				t.Fatalf("Read %s: %v", tc.file, err)
+			}
+			if !strings.Contains(string(content), tc.expected) {
+				t.Errorf("%s missing %q", tc.file, tc.expected)
+				t.Logf("Content:\n%s", content)
+			}
+		})
+	}
+}
+
+func TestWrite_LightModeMarker(t *testing.T) {
+	w, _ := newWriter(t)
+
+	path, err := w.Write("test-light", testProfile(), testPalette(processor.Light), sourceImage)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(path, theme.LightModeFile)); err != nil {
+		t.Errorf("Light theme should contain %s: %v", theme.LightModeFile, err)
+	}
+}
+
+func TestWrite_ExistingTheme(t *testing.T) {
+	w, dir := newWriter(t)
+	pal := testPalette(processor.Dark)
+
+	if _, err := w.Write("existing", testProfile(), pal, sourceImage); err != nil {
+		t.Fatalf("Initial write failed: %v", err)
+	}
+
+	_, err := w.Write("existing", testProfile(), pal, sourceImage)
+	if !errors.Is(err, themeerrors.ErrThemeExists) {
+		t.Errorf("Expected ErrThemeExists, got %v", err)
+	}
+
+	pal.Mode = processor.Light
+	path, err := w.Replace("existing", testProfile(), pal, sourceImage)
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(path, theme.LightModeFile)); err != nil {
+		t.Errorf("Replaced theme should reflect new palette: %v", err)
+	}
+
+	assertNoStaging(t, dir)
+}
+
+func TestWrite_FailureLeavesNoPartialTheme(t *testing.T) {
+	w, dir := newWriter(t)
+	pal := testPalette(processor.Dark)
+
+	_, err := w.Write("broken", testProfile(), pal, filepath.Join(t.TempDir(), "missing.jpg"))
+	if err == nil {
+		t.Fatal("Expected error for missing source image")
+	}
+
+	var writeErr *themeerrors.ThemeWriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("Expected ThemeWriteError, got %T", err)
+	}
+	t.Logf("Write error: %v (operation: %s)", err, writeErr.Operation)
+
+	if _, err := os.Stat(filepath.Join(dir, "broken")); !os.IsNotExist(err) {
+		t.Errorf("Failed write should not create theme directory")
+	}
+
+	assertNoStaging(t, dir)
+}
+
+func TestWrite_FailedReplaceKeepsOriginal(t *testing.T) {
+	w, _ := newWriter(t)
+	pal := testPalette(processor.Dark)
+
+	path, err := w.Write("keep", testProfile(), pal, sourceImage)
+	if err != nil {
+		t.Fatalf("Initial write failed: %v", err)
+	}
+
+	if _, err := w.Replace("keep", testProfile(), pal, filepath.Join(t.TempDir(), "missing.jpg")); err == nil {
+		t.Fatal("Expected error for missing source image")
+	}
+
+	for _, file := range theme.ComponentFiles {
+		if _, err := os.Stat(filepath.Join(path, file)); err != nil {
+			t.Errorf("Original theme lost %s after failed replace", file)
+		}
+	}
+}
+
+func TestWrite_InvalidNames(t *testing.T) {
+	w, _ := newWriter(t)
+	pal := testPalette(processor.Dark)
+
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`, ".hidden"} {
+		_, err := w.Write(name, testProfile(), pal, sourceImage)
+		if !errors.Is(err, themeerrors.ErrInvalidThemeName) {
+			t.Errorf("Name %q: expected ErrInvalidThemeName, got %v", name, err)
+		}
+	}
+}
+
+func TestThemesDir_Expansion(t *testing.T) {
+	s := settings.DefaultSettings()
+	w := theme.New(s)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("No home directory: %v", err)
+	}
+
+	expected := filepath.Join(home, ".config", "omarchy", "themes")
+	t.Logf("Default themes dir: %s -> %s", s.Theme.ThemesDir, w.ThemesDir())
+
+	if w.ThemesDir() != expected {
+		t.Errorf("Expected %s, got %s", expected, w.ThemesDir())
+	}
+}
+
+// Helper functions
+
+func newWriter(t *testing.T) (*theme.Writer, string) {
+	t.Helper()
+	dir := t.TempDir()
+	s := settings.DefaultSettings()
+	s.Theme.ThemesDir = dir
+	return theme.New(s), dir
+}
+
+func assertNoStaging(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			t.Errorf("Leftover staging entry: %s", e.Name())
+		}
+	}
+}
+
+func testPalette(mode processor.ThemeMode) *palette.SemanticPalette {
+	hex := func(s string) color.RGBA {
+		c, err := formats.ParseHex(s)
+		if err != nil {
+			panic(err)
+		}
+		return c
+	}
+
+	return &palette.SemanticPalette{
+		Mode:          mode,
+		Background:    hex("#24273a"),
+		Foreground:    hex("#cad3f5"),
+		DimForeground: hex("#8087a2"),
+		Cursor:        hex("#f4dbd6"),
+		Selection:     hex("#494d64"),
+		Border:        hex("#c6d0f5"),
+		Primary:       hex("#8aadf4"),
+		Secondary:     hex("#a6da95"),
+		Tertiary:      hex("#f5a97f"),
+		Terminal: palette.ANSIPalette{
+			Normal: palette.ANSIColors{
+				Black: hex("#494d64"), Red: hex("#ed8796"), Green: hex("#a6da95"), Yellow: hex("#eed49f"),
+				Blue: hex("#8aadf4"), Magenta: hex("#f5bde6"), Cyan: hex("#8bd5ca"), White: hex("#b8c0e0"),
+			},
+			Bright: palette.ANSIColors{
+				Black: hex("#5b6078"), Red: hex("#ed8796"), Green: hex("#a6da95"), Yellow: hex("#eed49f"),
+				Blue: hex("#8aadf4"), Magenta: hex("#f5bde6"), Cyan: hex("#8bd5ca"), White: hex("#a5adcb"),
+			},
+		},
+	}
+}
+
+// testProfile returns a dark profile whose clusters match the test palette.
+func testProfile() *processor.ColorProfile {
+	p := processor.New(settings.DefaultSettings())
+	clusters := []struct {
+		hex    string
+		weight float64
+	}{
+		{"#24273a", 0.55},
+		{"#cad3f5", 0.2},
+		{"#8aadf4", 0.12},
+		{"#a6da95", 0.08},
+		{"#f5a97f", 0.05},
+	}
+
+	profile := &processor.ColorProfile{
+		Mode:         processor.Dark,
+		HasColor:     true,
+		UniqueColors: 1247,
+		SampleCount:  10000,
+	}
+	for _, c := range clusters {
+		rgba, err := formats.ParseHex(c.hex)
+		if err != nil {
+			panic(err)
+		}
+		profile.Colors = append(profile.Colors, p.NewCluster(rgba, c.weight))
+	}
+	profile.ColorCount = len(profile.Colors)
+
+	return profile
+}